@@ -3,10 +3,12 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -32,20 +34,20 @@ type tokenResponse struct {
 // accessToken returns a valid access token, refreshing it if it's missing or
 // close to expiring.
 //
-// A token supplied via WithAccessToken (accessTokenExpiration left zero) is
+// A token supplied via WithAccessToken (expiration left zero) is
 // trusted as-is with no proactive expiration check - we don't know its real
 // expiry, so it's used until a request actually fails with 401. Once this
-// client performs its own exchange, accessTokenExpiration is set to a real
+// client performs its own exchange, the expiration is set to a real
 // value and checked proactively from then on.
 func (c *Client) accessTokenFor(ctx context.Context) (string, error) {
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
 
-	if c.accessToken != "" && (c.accessTokenExpiration.IsZero() || time.Now().Before(c.accessTokenExpiration.Add(-tokenExpirationBuffer))) {
-		return c.accessToken, nil
+	if c.tokens.accessToken != "" && (c.tokens.expiration.IsZero() || time.Now().Before(c.tokens.expiration.Add(-tokenExpirationBuffer))) {
+		return c.tokens.accessToken, nil
 	}
 
-	if c.refreshToken == "" {
+	if c.tokens.refreshToken == "" {
 		return "", fmt.Errorf("no refresh token configured: set REFRESH_TOKEN (see client.WithRefreshToken)")
 	}
 
@@ -53,7 +55,7 @@ func (c *Client) accessTokenFor(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	return c.accessToken, nil
+	return c.tokens.accessToken, nil
 }
 
 // AccessToken returns the access token currently held by the client. If this
@@ -62,9 +64,9 @@ func (c *Client) accessTokenFor(ctx context.Context) (string, error) {
 // to persist a refreshed access token across process runs (e.g. back into a
 // secret store) should read it from here once requests are done.
 func (c *Client) AccessToken() string {
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
-	return c.accessToken
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	return c.tokens.accessToken
 }
 
 // RefreshToken returns the refresh token currently held by the client. The
@@ -75,26 +77,60 @@ func (c *Client) AccessToken() string {
 // back into a secret store) must read it from here once requests are done
 // and persist it; reusing the original value on the next run will fail.
 func (c *Client) RefreshToken() string {
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
-	return c.refreshToken
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	return c.tokens.refreshToken
+}
+
+// UserID returns the ID of the user the access token was issued to, read
+// from the token's JWT subject. It fetches a token first if the client
+// doesn't hold one yet.
+func (c *Client) UserID(ctx context.Context) (string, error) {
+	token, err := c.accessTokenFor(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tokenSubject(token)
+}
+
+// tokenSubject returns the "sub" claim of a JWT without verifying its
+// signature; the token came from the auth endpoint, not from a caller.
+func tokenSubject(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("access token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return "", fmt.Errorf("decoding access token payload: %w", err)
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("decoding access token claims: %w", err)
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("access token has no subject")
+	}
+	return claims.Subject, nil
 }
 
 // invalidateAccessToken forces the next accessTokenFor call to fetch a fresh
 // token, used when a request unexpectedly comes back 401 mid-run.
 func (c *Client) invalidateAccessToken() {
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
-	c.accessToken = ""
-	c.accessTokenExpiration = time.Time{}
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+	c.tokens.accessToken = ""
+	c.tokens.expiration = time.Time{}
 }
 
 // refreshAccessTokenLocked exchanges the refresh token for a new access
-// token. Callers must hold c.tokenMu.
+// token. Callers must hold c.tokens.mu.
 func (c *Client) refreshAccessTokenLocked(ctx context.Context) error {
 	reqBody, err := json.Marshal(tokenRequest{
 		RequestedUserRoles: []string{"ROLE_CUSTOMER"},
-		RefreshToken:       c.refreshToken,
+		RefreshToken:       c.tokens.refreshToken,
 	})
 	if err != nil {
 		return fmt.Errorf("encoding token request: %w", err)
@@ -137,14 +173,14 @@ func (c *Client) refreshAccessTokenLocked(ctx context.Context) error {
 		return fmt.Errorf("parsing access_token_expiration %q: %w", tr.AccessTokenExpiration, err)
 	}
 
-	c.accessToken = tr.AccessToken
-	c.accessTokenExpiration = expiration
+	c.tokens.accessToken = tr.AccessToken
+	c.tokens.expiration = expiration
 	if tr.RefreshToken != "" {
 		// The API rotates the refresh token on every exchange and invalidates
 		// the one we just used - keep using the new one for the rest of this
 		// process's lifetime, and let the caller (RefreshToken) read it back
 		// to persist it, or the next exchange will fail.
-		c.refreshToken = tr.RefreshToken
+		c.tokens.refreshToken = tr.RefreshToken
 	}
 
 	return nil
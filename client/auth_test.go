@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -79,8 +80,8 @@ func TestAccessTokenForAdoptsRotatedRefreshToken(t *testing.T) {
 	if c.RefreshToken() != "rotated-refresh-token" {
 		t.Errorf("expected RefreshToken() to reflect the rotated token, got %s", c.RefreshToken())
 	}
-	if c.refreshToken != "rotated-refresh-token" {
-		t.Errorf("expected internal refreshToken to be updated for future exchanges, got %s", c.refreshToken)
+	if c.tokens.refreshToken != "rotated-refresh-token" {
+		t.Errorf("expected internal refreshToken to be updated for future exchanges, got %s", c.tokens.refreshToken)
 	}
 }
 
@@ -278,3 +279,60 @@ func TestSendRequestGivesUpAfterSecond401(t *testing.T) {
 		t.Errorf("expected status 401, got %d", apiErr.StatusCode)
 	}
 }
+
+func TestWithVersionSharesTokens(t *testing.T) {
+	var tokenCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		resp := tokenResponse{
+			AccessToken:           "access-1",
+			AccessTokenExpiration: time.Now().Add(time.Hour).UTC().Format(tokenExpirationLayout),
+			RefreshToken:          "rotated-refresh-token",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithAuthBaseURL(server.URL), WithRefreshToken("original-refresh-token"))
+	v2 := c.WithVersion(DefaultBaseUrlV2)
+
+	if _, err := v2.accessTokenFor(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.accessTokenFor(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// An exchange through either client rotates the refresh token for both,
+	// so the original client can still persist it.
+	if calls := atomic.LoadInt32(&tokenCalls); calls != 1 {
+		t.Errorf("expected 1 token exchange, got %d", calls)
+	}
+	if c.RefreshToken() != "rotated-refresh-token" {
+		t.Errorf("expected the rotated refresh token, got %s", c.RefreshToken())
+	}
+	if v2.baseURL != DefaultBaseUrlV2 || c.baseURL != DefaultBaseUrlV1 {
+		t.Errorf("expected base URLs v2 and v1, got %s and %s", v2.baseURL, c.baseURL)
+	}
+}
+
+func TestUserIDReadsTokenSubject(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"12345","exp":1790000000}`))
+	c := NewClient(WithRefreshToken("refresh-token"), WithAccessToken("eyJhbGciOiJIUzI1NiJ9."+payload+".sig"))
+
+	id, err := c.UserID(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id != "12345" {
+		t.Errorf("expected 12345, got %s", id)
+	}
+
+	for _, token := range []string{"opaque-token", "a." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".c"} {
+		c := NewClient(WithRefreshToken("refresh-token"), WithAccessToken(token))
+		if _, err := c.UserID(context.Background()); err == nil {
+			t.Errorf("expected an error for %q", token)
+		}
+	}
+}
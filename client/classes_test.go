@@ -27,8 +27,8 @@ func TestGetClasses(t *testing.T) {
 			{
 				AcademyClassID: "class-123",
 				SportID:        "PADEL",
-				StartDate:      "2023-01-01T10:00:00",
-				EndDate:        "2023-01-01T12:00:00",
+				StartDate:      models.MustParseTime("2023-01-01T10:00:00"),
+				EndDate:        models.MustParseTime("2023-01-01T12:00:00"),
 				Type:           "COURSE",
 				CourseSummary: &models.CourseSummary{
 					CourseID:   "course-456",
@@ -34,12 +34,17 @@ type Client struct {
 	userAgent  string
 	maxRetries int
 	debug      bool
+	drift      *DriftReport
+	tokens     *tokens
+}
 
+// tokens is the auth state of a client, shared with the clients derived
+// from it by WithVersion.
+type tokens struct {
+	mu           sync.Mutex
 	refreshToken string
-
-	tokenMu               sync.Mutex
-	accessToken           string
-	accessTokenExpiration time.Time
+	accessToken  string
+	expiration   time.Time
 }
 
 // NewClient creates a new Playtomic API client with the given options
@@ -52,6 +57,7 @@ func NewClient(opts ...Option) *Client {
 		authURL:    DefaultAuthBaseURL,
 		userAgent:  DefaultUserAgent,
 		maxRetries: DefaultMaxRetries,
+		tokens:     &tokens{},
 	}
 
 	// Apply options
@@ -61,3 +67,19 @@ func NewClient(opts ...Option) *Client {
 
 	return c
 }
+
+// WithVersion returns a client for another API base URL, such as
+// DefaultBaseUrlV2, that shares c's tokens and drift report. Endpoints live
+// under different API versions, and a refresh through one client rotates
+// the refresh token for both.
+func (c *Client) WithVersion(baseURL string) *Client {
+	derived := *c
+	derived.baseURL = baseURL
+	return &derived
+}
+
+// DriftReport returns the schema drift recorded so far, or nil unless the
+// client was created with WithDriftDetection.
+func (c *Client) DriftReport() *DriftReport {
+	return c.drift
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// upcomingClassStatus restricts coach and course lookups to classes that
+// haven't finished yet.
+var upcomingClassStatus = []models.ClassStatus{models.ClassStatusPending, models.ClassStatusInProgress}
+
+// GetCoaches lists the coaches teaching upcoming classes at a tenant.
+//
+// The API has no coach directory endpoint, so the list is derived from the
+// coaches attached to the tenant's classes (see models.CoachesFromClasses).
+func (c *Client) GetCoaches(ctx context.Context, tenantID string) ([]models.Coach, error) {
+	classes, err := c.GetClasses(ctx, &models.SearchClassesParams{
+		TenantIDs: []string{tenantID},
+		Status:    upcomingClassStatus,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching coaches: %w", err)
+	}
+	return models.CoachesFromClasses(classes), nil
+}
+
+// GetCoachSchedules builds the upcoming schedule of every coach at a tenant,
+// aggregating the tenant's classes and lessons keyed on Coach.UserID.
+// fromStartDate is optional and uses Playtomic's time format
+// ("2006-01-02T15:04:05").
+//
+// Classes are served by API v1 and lessons by v2, so it takes a client for
+// each, usually c and c.WithVersion(DefaultBaseUrlV2).
+func GetCoachSchedules(ctx context.Context, classes, lessons *Client, tenantID, fromStartDate string) ([]models.CoachSchedule, error) {
+	tenantClasses, err := classes.GetClasses(ctx, &models.SearchClassesParams{
+		TenantIDs:      []string{tenantID},
+		Status:         upcomingClassStatus,
+		IncludeSummary: true,
+		FromStartDate:  fromStartDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching coach schedules: %w", err)
+	}
+
+	tenantLessons, err := lessons.GetLessons(ctx, &models.SearchLessonsParams{
+		TenantID:      tenantID,
+		FromStartDate: fromStartDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching coach schedules: %w", err)
+	}
+
+	return models.BuildCoachSchedules(tenantClasses, tenantLessons), nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+func TestGetCoachSchedules(t *testing.T) {
+	coach := models.Coach{UserID: "coach-1", Name: "Ana"}
+
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("tenant_id") != "test-tenant-id" {
+			t.Errorf("Expected tenant_id query param to be 'test-tenant-id', got '%s'", query.Get("tenant_id"))
+		}
+		if query.Get("from_start_date") != "2026-05-01T00:00:00" {
+			t.Errorf("Expected from_start_date query param to be '2026-05-01T00:00:00', got '%s'", query.Get("from_start_date"))
+		}
+
+		var mockResponse interface{}
+		switch r.URL.Path {
+		case "/v1/classes":
+			mockResponse = []models.Class{
+				{AcademyClassID: "class-1", StartDate: models.MustParseTime("2026-05-02T18:00:00"), Coaches: []models.Coach{coach}},
+			}
+		case "/v2/lessons":
+			mockResponse = []models.Lesson{
+				{TournamentID: "lesson-1", StartDate: models.MustParseTime("2026-05-01T10:00:00"), Coaches: []models.Coach{coach}},
+			}
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, WithBaseURL(server.URL+"/v1"))
+
+	schedules, err := GetCoachSchedules(context.Background(), client, client.WithVersion(server.URL+"/v2"), "test-tenant-id", "2026-05-01T00:00:00")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(schedules) != 1 {
+		t.Fatalf("Expected 1 schedule, got %d", len(schedules))
+	}
+	if schedules[0].Coach.UserID != "coach-1" {
+		t.Errorf("Expected coach UserID 'coach-1', got %s", schedules[0].Coach.UserID)
+	}
+	if len(schedules[0].Sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(schedules[0].Sessions))
+	}
+	if schedules[0].Sessions[0].ID != "lesson-1" {
+		t.Errorf("Expected first session 'lesson-1', got %s", schedules[0].Sessions[0].ID)
+	}
+}
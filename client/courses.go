@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// GetCourses retrieves classes matching params and groups them into courses
+// (see models.GroupCourses). IncludeSummary is forced on, since courses are
+// identified by the class's course summary.
+func (c *Client) GetCourses(ctx context.Context, params *models.SearchClassesParams) ([]models.Course, error) {
+	params.IncludeSummary = true
+
+	classes, err := c.GetClasses(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("fetching courses: %w", err)
+	}
+	return models.GroupCourses(classes), nil
+}
+
+// GetCourse retrieves a single course with all of its upcoming sessions at a
+// tenant. It returns an error if the tenant has no sessions for courseID.
+func (c *Client) GetCourse(ctx context.Context, tenantID, courseID string) (*models.Course, error) {
+	courses, err := c.GetCourses(ctx, &models.SearchClassesParams{
+		TenantIDs: []string{tenantID},
+		Status:    upcomingClassStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range courses {
+		if courses[i].CourseID == courseID {
+			return &courses[i], nil
+		}
+	}
+	return nil, fmt.Errorf("course %s not found for tenant %s", courseID, tenantID)
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+func TestGetCourse(t *testing.T) {
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/classes" {
+			t.Errorf("Expected path /classes, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("include_summary") != "true" {
+			t.Errorf("Expected include_summary query param to be 'true', got '%s'", r.URL.Query().Get("include_summary"))
+		}
+
+		mockResponse := []models.Class{
+			{AcademyClassID: "class-1", CourseSummary: &models.CourseSummary{CourseID: "course-1", Name: "Beginner", MaxPlayers: 4}},
+			{AcademyClassID: "class-2", CourseSummary: &models.CourseSummary{CourseID: "course-2", Name: "Advanced", MaxPlayers: 4}},
+			{AcademyClassID: "class-3", CourseSummary: &models.CourseSummary{CourseID: "course-1", Name: "Beginner", MaxPlayers: 4}},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	course, err := client.GetCourse(context.Background(), "test-tenant-id", "course-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if course.Name != "Beginner" {
+		t.Errorf("Expected course name 'Beginner', got %s", course.Name)
+	}
+	if len(course.Sessions) != 2 {
+		t.Errorf("Expected 2 sessions, got %d", len(course.Sessions))
+	}
+
+	if _, err := client.GetCourse(context.Background(), "test-tenant-id", "missing"); err == nil {
+		t.Error("Expected error for unknown course, got nil")
+	}
+}
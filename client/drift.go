@@ -0,0 +1,216 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DriftReport collects differences between API responses and the models
+// they're decoded into: fields the API sent that no model field maps to
+// (unknown), and model fields without omitempty that the API didn't send
+// (missing). Both are keyed by model type, e.g. "models.Class".
+//
+// Playtomic changes its API without notice; a non-empty report is the early
+// warning that a filter may be about to silently stop matching.
+type DriftReport struct {
+	mu      sync.Mutex
+	unknown map[string]map[string]bool
+	missing map[string]map[string]bool
+}
+
+func newDriftReport() *DriftReport {
+	return &DriftReport{
+		unknown: make(map[string]map[string]bool),
+		missing: make(map[string]map[string]bool),
+	}
+}
+
+// Unknown returns the JSON fields received for each model type that don't
+// map to any of its fields, sorted by name.
+func (r *DriftReport) Unknown() map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortedFields(r.unknown)
+}
+
+// Missing returns the model fields (by JSON name) that were expected but
+// absent from at least one response, sorted by name.
+func (r *DriftReport) Missing() map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortedFields(r.missing)
+}
+
+// Empty reports whether no drift has been recorded.
+func (r *DriftReport) Empty() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.unknown) == 0 && len(r.missing) == 0
+}
+
+// String renders the report one model type per line, e.g.
+// "models.Class: unknown [foo]; missing [start_date]".
+func (r *DriftReport) String() string {
+	unknown, missing := r.Unknown(), r.Missing()
+
+	types := make(map[string]bool)
+	for t := range unknown {
+		types[t] = true
+	}
+	for t := range missing {
+		types[t] = true
+	}
+	names := make([]string, 0, len(types))
+	for t := range types {
+		names = append(names, t)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, t := range names {
+		var parts []string
+		if f := unknown[t]; len(f) > 0 {
+			parts = append(parts, fmt.Sprintf("unknown [%s]", strings.Join(f, ", ")))
+		}
+		if f := missing[t]; len(f) > 0 {
+			parts = append(parts, fmt.Sprintf("missing [%s]", strings.Join(f, ", ")))
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", t, strings.Join(parts, "; "))
+	}
+	return sb.String()
+}
+
+// check compares a raw response body against the type of result (what it
+// was decoded into) and records any drift.
+func (r *DriftReport) check(body []byte, result interface{}) {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.walk(raw, reflect.TypeOf(result))
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// walk descends into raw following t. Types with their own JSON decoding
+// (models.Time, models.Money, ...), maps and interfaces are opaque.
+func (r *DriftReport) walk(raw interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			r.walk(item, t.Elem())
+		}
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		r.walkStruct(obj, t)
+	}
+}
+
+func (r *DriftReport) walkStruct(obj map[string]interface{}, t reflect.Type) {
+	fields := jsonFields(t)
+	typeName := t.String()
+
+	for key, value := range obj {
+		f, ok := fields[key]
+		if !ok {
+			// encoding/json falls back to a case-insensitive match.
+			for name, candidate := range fields {
+				if strings.EqualFold(name, key) {
+					f, ok = candidate, true
+					break
+				}
+			}
+		}
+		if !ok {
+			record(r.unknown, typeName, key)
+			continue
+		}
+		if value != nil {
+			r.walk(value, f.typ)
+		}
+	}
+
+	for name, f := range fields {
+		if f.omitempty {
+			continue
+		}
+		if _, ok := obj[name]; !ok {
+			record(r.missing, typeName, name)
+		}
+	}
+}
+
+type jsonField struct {
+	typ       reflect.Type
+	omitempty bool
+}
+
+// jsonFields maps JSON names to the exported fields of struct type t,
+// including fields promoted from embedded structs.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			for k, v := range jsonFields(sf.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields[name] = jsonField{typ: sf.Type, omitempty: strings.Contains(opts, "omitempty")}
+	}
+	return fields
+}
+
+func record(m map[string]map[string]bool, typeName, field string) {
+	if m[typeName] == nil {
+		m[typeName] = make(map[string]bool)
+	}
+	m[typeName][field] = true
+}
+
+func sortedFields(m map[string]map[string]bool) map[string][]string {
+	result := make(map[string][]string, len(m))
+	for t, fields := range m {
+		names := make([]string, 0, len(fields))
+		for f := range fields {
+			names = append(names, f)
+		}
+		sort.Strings(names)
+		result[t] = names
+	}
+	return result
+}
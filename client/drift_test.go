@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+func TestDriftDetectionRecordsUnknownAndMissingFields(t *testing.T) {
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// payment_id is missing, loyalty_points is new, and the price is a
+		// models.Money string that must not be descended into.
+		w.Write([]byte(`[{
+			"payment_method_id": "pm1",
+			"payment_method_type": "CREDIT_CARD",
+			"onsite_payment_method_type": null,
+			"b2b_billing_type": "NONE",
+			"user_vat": 21,
+			"tenant_vat": 21,
+			"commission_model": "DEFAULT",
+			"refund_id": null,
+			"payment_price": "36 EUR",
+			"payment_reference": null,
+			"payer_id": "u1",
+			"payment_date": "2024-05-01T10:00:00",
+			"loyalty_points": 12
+		}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, WithDriftDetection(true))
+
+	if _, err := client.GetPayments(context.Background(), &models.SearchPaymentsParams{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report := client.DriftReport()
+	if report.Empty() {
+		t.Fatal("Expected drift to be recorded")
+	}
+	if got := report.Unknown()["models.Payment"]; !reflect.DeepEqual(got, []string{"loyalty_points"}) {
+		t.Errorf("Expected unknown [loyalty_points], got %v", got)
+	}
+	if got := report.Missing()["models.Payment"]; !reflect.DeepEqual(got, []string{"payment_id"}) {
+		t.Errorf("Expected missing [payment_id], got %v", got)
+	}
+	if s := report.String(); !strings.Contains(s, "models.Payment: unknown [loyalty_points]; missing [payment_id]") {
+		t.Errorf("Unexpected report: %q", s)
+	}
+}
+
+func TestDriftDetectionDisabledByDefault(t *testing.T) {
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.GetPayments(context.Background(), &models.SearchPaymentsParams{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.DriftReport() != nil {
+		t.Error("Expected no drift report without WithDriftDetection")
+	}
+}
+
+func TestDriftReportEmbeddedFields(t *testing.T) {
+	report := newDriftReport()
+
+	// Player embeds BasePlayer, whose fields must count as known.
+	body := []byte(`{"user_id": "u1", "name": "Ana", "level_value": 3.5, "gender": "FEMALE", "extra": true}`)
+	report.check(body, &models.Player{})
+
+	unknown := report.Unknown()
+	if got := unknown["models.Player"]; !reflect.DeepEqual(got, []string{"extra"}) {
+		t.Errorf("Expected unknown [extra] for models.Player, got %v", unknown)
+	}
+}
@@ -2,9 +2,14 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
+// ErrTruncated reports that a paginated listing stopped at its page limit
+// with more results left. The results fetched so far are returned with it.
+var ErrTruncated = errors.New("results truncated at the page limit")
+
 // APIError represents an error returned by the Playtomic API
 type APIError struct {
 	StatusCode int
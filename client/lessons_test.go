@@ -27,8 +27,8 @@ func TestGetLessons(t *testing.T) {
 			{
 				TournamentID:   "lesson-123",
 				TournamentName: "Test Lesson",
-				StartDate:      "2023-01-01T10:00:00",
-				EndDate:        "2023-01-01T12:00:00",
+				StartDate:      models.MustParseTime("2023-01-01T10:00:00"),
+				EndDate:        models.MustParseTime("2023-01-01T12:00:00"),
 				Type:           "CLASS",
 				MinPlayers:     2,
 				MaxPlayers:     4,
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/rafa-garcia/go-playtomic-api/models"
+	"github.com/rafa-garcia/go-playtomic-api/models/fixtures"
 )
 
 func TestGetMatches(t *testing.T) {
@@ -26,56 +27,15 @@ func TestGetMatches(t *testing.T) {
 			t.Errorf("Expected sport_id query param to be 'PADEL', got '%s'", query.Get("sport_id"))
 		}
 
+		tenant := fixtures.Tenant().WithID("test-tenant-id-1").Build()
 		mockResponse := []models.Match{
-			{
-				MatchID:           "match-123",
-				SportID:           "PADEL",
-				StartDate:         "2023-01-01T10:00:00",
-				EndDate:           "2023-01-01T12:00:00",
-				MatchType:         "COMPETITIVE",
-				MinPlayersPerTeam: 2,
-				MaxPlayersPerTeam: 2,
-				MinLevel:          2.5,
-				MaxLevel:          4.0,
-				Gender:            "MIXED",
-				Price:             "30 EUR",
-				Teams: []models.Team{
-					{
-						TeamID:     "0",
-						MinPlayers: 2,
-						MaxPlayers: 2,
-						Players: []models.Player{
-							{
-								BasePlayer: models.BasePlayer{
-									UserID:     "user-123",
-									LevelValue: 3.5,
-									Picture:    "profile1.jpg",
-								},
-								Name: "Player One",
-							},
-						},
-					},
-					{
-						TeamID:     "1",
-						MinPlayers: 2,
-						MaxPlayers: 2,
-						Players: []models.Player{
-							{
-								BasePlayer: models.BasePlayer{
-									UserID:     "user-456",
-									LevelValue: 3.0,
-									Picture:    "profile2.jpg",
-								},
-								Name: "Player Two",
-							},
-						},
-					},
-				},
-				Tenant: models.Tenant{
-					TenantID:   "test-tenant-id-1",
-					TenantName: "Test Club",
-				},
-			},
+			fixtures.Match().
+				WithID("match-123").
+				Levels(2.5, 4.0).
+				WithPlayer(0, fixtures.Player("Player One", 3.5)).
+				WithPlayer(1, fixtures.Player("Player Two", 3.0)).
+				AtTenant(tenant).
+				Build(),
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -125,8 +85,8 @@ func TestGetMatches(t *testing.T) {
 	}
 
 	player1 := team1.Players[0]
-	if player1.UserID != "user-123" {
-		t.Errorf("Expected player UserID 'user-123', got %s", player1.UserID)
+	if player1.UserID != "user-player-one" {
+		t.Errorf("Expected player UserID 'user-player-one', got %s", player1.UserID)
 	}
 	if player1.Name != "Player One" {
 		t.Errorf("Expected player Name 'Player One', got %s", player1.Name)
@@ -28,7 +28,7 @@ func WithAuthBaseURL(url string) Option {
 // the Playtomic API requires a Bearer access token on every call.
 func WithRefreshToken(refreshToken string) Option {
 	return func(c *Client) {
-		c.refreshToken = refreshToken
+		c.tokens.refreshToken = refreshToken
 	}
 }
 
@@ -39,7 +39,7 @@ func WithRefreshToken(refreshToken string) Option {
 // the client exchanges the refresh token for an access token on first use.
 func WithAccessToken(accessToken string) Option {
 	return func(c *Client) {
-		c.accessToken = accessToken
+		c.tokens.accessToken = accessToken
 	}
 }
 
@@ -64,6 +64,20 @@ func WithDebug(enabled bool) Option {
 	}
 }
 
+// WithDriftDetection enables strict decoding: every response is also
+// compared field by field against the model it's decoded into, and unknown
+// or missing fields are collected in the client's DriftReport. Decoding
+// itself stays lenient, so drift never fails a request.
+func WithDriftDetection(enabled bool) Option {
+	return func(c *Client) {
+		if enabled {
+			c.drift = newDriftReport()
+		} else {
+			c.drift = nil
+		}
+	}
+}
+
 // WithUserAgent sets a custom User-Agent header
 func WithUserAgent(userAgent string) Option {
 	return func(c *Client) {
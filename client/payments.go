@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+const (
+	// paymentsPageSize is the page size requested when listing payments.
+	paymentsPageSize = 50
+
+	// maxPaymentsPages caps pagination, as with maxClassesPages.
+	maxPaymentsPages = 100
+)
+
+// GetPayments retrieves the caller's payments and refunds, paging through
+// results until a short page is returned. Size and Page are managed here.
+// Use models.FilterPaymentsByDate to restrict the result to a date range.
+//
+// Payments come in the API's default order, which it doesn't specify, unless
+// params.Sort asks for one (e.g. "payment_date,DESC"). If maxPaymentsPages
+// fill up, the payments fetched so far are returned with an error wrapping
+// ErrTruncated.
+func (c *Client) GetPayments(ctx context.Context, params *models.SearchPaymentsParams) ([]models.Payment, error) {
+	params.Size = paymentsPageSize
+
+	var payments []models.Payment
+	for page := 0; page < maxPaymentsPages; page++ {
+		params.Page = page
+
+		var pagePayments []models.Payment
+		err := c.sendRequest(ctx, http.MethodGet, "/payments", params.ToURLValues().Encode(), nil, &pagePayments)
+		if err != nil {
+			return nil, fmt.Errorf("fetching payments: %w", err)
+		}
+
+		payments = append(payments, pagePayments...)
+
+		if len(pagePayments) < paymentsPageSize {
+			return payments, nil
+		}
+	}
+
+	return payments, fmt.Errorf("fetching payments: %w after %d pages", ErrTruncated, maxPaymentsPages)
+}
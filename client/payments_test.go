@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+func TestGetPaymentsPaginates(t *testing.T) {
+	var pages []string
+
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/payments" {
+			t.Errorf("Expected path /payments, got %s", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("user_id") != "me" {
+			t.Errorf("Expected user_id query param to be 'me', got '%s'", query.Get("user_id"))
+		}
+		pages = append(pages, query.Get("page"))
+
+		// First page is full, second is short.
+		count := paymentsPageSize
+		if query.Get("page") == "1" {
+			count = 3
+		}
+		mockResponse := make([]models.Payment, count)
+		for i := range mockResponse {
+			mockResponse[i] = models.Payment{PaymentID: query.Get("page") + "-" + strconv.Itoa(i), PaymentPrice: models.MustParseMoney("36 EUR")}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	payments, err := client.GetPayments(context.Background(), &models.SearchPaymentsParams{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(payments) != paymentsPageSize+3 {
+		t.Errorf("Expected %d payments, got %d", paymentsPageSize+3, len(payments))
+	}
+	if len(pages) != 2 || pages[0] != "0" || pages[1] != "1" {
+		t.Errorf("Expected pages [0 1], got %v", pages)
+	}
+}
+
+func TestGetPaymentsStopsAtPageLimit(t *testing.T) {
+	requests := 0
+
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Every page is full, so only the page limit ends the listing.
+		mockResponse := make([]models.Payment, paymentsPageSize)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	payments, err := client.GetPayments(context.Background(), &models.SearchPaymentsParams{})
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("Expected ErrTruncated, got %v", err)
+	}
+	if requests != maxPaymentsPages {
+		t.Errorf("Expected %d requests, got %d", maxPaymentsPages, requests)
+	}
+	if len(payments) != maxPaymentsPages*paymentsPageSize {
+		t.Errorf("Expected the %d payments fetched so far, got %d", maxPaymentsPages*paymentsPageSize, len(payments))
+	}
+}
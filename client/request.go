@@ -24,6 +24,10 @@ func (c *Client) sendRequest(ctx context.Context, method, endpoint string, query
 		return fmt.Errorf("decoding response: %w", err)
 	}
 
+	if c.drift != nil {
+		c.drift.check(respBody, result)
+	}
+
 	return nil
 }
 
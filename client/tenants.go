@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// GetTenant retrieves a single club by ID, including its address and time
+// zone.
+func (c *Client) GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	var tenant models.Tenant
+	err := c.sendRequest(ctx, http.MethodGet, "/tenants/"+url.PathEscape(tenantID), "", nil, &tenant)
+	if err != nil {
+		return nil, fmt.Errorf("fetching tenant %s: %w", tenantID, err)
+	}
+	return &tenant, nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rafa-garcia/go-playtomic-api/models/fixtures"
+)
+
+func TestGetTenant(t *testing.T) {
+	server := newAuthTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenants/tenant-1" {
+			t.Errorf("Expected path /tenants/tenant-1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(fixtures.JSON(fixtures.Tenant().WithID("tenant-1").WithTimezone("Europe/Madrid").Build()))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	tenant, err := client.GetTenant(context.Background(), "tenant-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tenant.TenantID != "tenant-1" {
+		t.Errorf("Expected tenant ID 'tenant-1', got %s", tenant.TenantID)
+	}
+	if got := tenant.Address.Location().String(); got != "Europe/Madrid" {
+		t.Errorf("Expected location Europe/Madrid, got %s", got)
+	}
+}
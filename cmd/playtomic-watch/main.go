@@ -2,11 +2,17 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/rafa-garcia/go-playtomic-api/client"
@@ -24,8 +30,9 @@ func main() {
 // run contains all program logic and returns the process exit code. Using a
 // dedicated function (instead of calling os.Exit directly from main) ensures
 // deferred cleanup - like saving state files - always runs before exit.
-func run() int {
+func run() (exitCode int) {
 	configPath := flag.String("config", "config.yaml", "path to configuration file")
+	profile := flag.String("profile", "", "config profile to apply, from the config's profiles: section")
 	timeout := flag.Duration("timeout", 30*time.Second, "HTTP request timeout")
 	telegramToken := flag.String("telegram-token", "", "Telegram bot token")
 	telegramChatID := flag.String("telegram-chat-id", "", "Telegram chat ID")
@@ -34,8 +41,16 @@ func run() int {
 	tournamentStatePath := flag.String("tournament-state", "tournament-state.json", "path to tournament state file")
 	classStatePath := flag.String("class-state", "class-state.json", "path to class state file")
 	courtStatePath := flag.String("court-state", "court-state.json", "path to court state file")
+	matchStatePath := flag.String("match-state", "match-state.json", "path to match state file")
+	lessonStatePath := flag.String("lesson-state", "lesson-state.json", "path to lesson state file")
+	explain := flag.Bool("explain", false, "print every fetched item and which filter rule kept or dropped it")
+	drift := flag.String("drift", "", "schema drift detection: 'log' reports API fields the models don't match, 'fail' also exits non-zero (default off)")
 	flag.Parse()
 
+	if *drift != "" && *drift != "log" && *drift != "fail" {
+		log.Fatalf("Error: invalid -drift value '%s' (want log or fail)", *drift)
+	}
+
 	// Check for subcommand
 	args := flag.Args()
 	if len(args) == 0 {
@@ -44,7 +59,7 @@ func run() int {
 	}
 
 	subcommand := args[0]
-	if subcommand != "tournaments" && subcommand != "classes" && subcommand != "courts" {
+	if subcommand != "tournaments" && subcommand != "classes" && subcommand != "courts" && subcommand != "coaches" && subcommand != "matches" && subcommand != "lessons" && subcommand != "payments" {
 		printUsage()
 		log.Fatalf("Error: invalid subcommand '%s'", subcommand)
 	}
@@ -53,9 +68,16 @@ func run() int {
 		log.Fatalf("Error: refresh token required (set REFRESH_TOKEN env var or -refresh-token flag)")
 	}
 
-	cfg, err := config.Load(*configPath)
-	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+	// payments reads the caller's own account and takes its options as
+	// subcommand flags, so it doesn't need a config file.
+	var cfg *config.Config
+	if subcommand != "payments" {
+		var err error
+		cfg, err = config.LoadProfile(*configPath, *profile)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		displayLocation = loadDisplayLocation(cfg.Timezone)
 	}
 
 	var bot *telegram.Bot
@@ -82,11 +104,23 @@ func run() int {
 	// refresh token (the API rotates and invalidates the old one on every
 	// exchange) get exported so CI can persist them back into the
 	// ACCESS_TOKEN/REFRESH_TOKEN secrets for the next scheduled run.
+	//
+	// With -drift, any schema drift the client saw is logged here too, and
+	// -drift=fail turns it into a failing exit code so CI catches API changes
+	// before they silently break filters.
 	var activeClient *client.Client
 	defer func() {
-		if activeClient != nil {
-			exportRotatedToken("ROTATED_ACCESS_TOKEN", *accessToken, activeClient.AccessToken())
-			exportRotatedToken("ROTATED_REFRESH_TOKEN", *refreshToken, activeClient.RefreshToken())
+		if activeClient == nil {
+			return
+		}
+		exportRotatedToken("ROTATED_ACCESS_TOKEN", *accessToken, activeClient.AccessToken())
+		exportRotatedToken("ROTATED_REFRESH_TOKEN", *refreshToken, activeClient.RefreshToken())
+
+		if report := activeClient.DriftReport(); report != nil && !report.Empty() {
+			log.Printf("Schema drift detected:\n%s", report)
+			if *drift == "fail" {
+				exitCode = 1
+			}
 		}
 	}()
 
@@ -113,8 +147,13 @@ func run() int {
 			client.WithBaseURL(client.DefaultBaseUrlV2),
 			client.WithRefreshToken(*refreshToken),
 			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
 		)
 		activeClient = v2Client
+		if err := resolveMe(ctx, v2Client, cfg); err != nil {
+			log.Printf("Failed to identify user: %v", err)
+			return 1
+		}
 
 		var matchedTournaments []models.Tournament
 		for _, tf := range cfg.Tournaments {
@@ -125,6 +164,9 @@ func run() int {
 				continue
 			}
 
+			if *explain {
+				writeExplain(os.Stdout, "tournaments at "+cfg.TenantName(tf.TenantID), filter.Explain(tournaments, tf))
+			}
 			matchedTournaments = append(matchedTournaments, filter.Apply(tournaments, tf)...)
 		}
 
@@ -136,13 +178,17 @@ func run() int {
 			return 0
 		}
 
+		if origin, ok := cfg.RankOrigin(); ok {
+			models.SortByDistance(matchedTournaments, origin)
+		}
+
 		for _, t := range matchedTournaments {
-			printTournament(t)
+			printTournament(t, cfg.Friends)
 
 			// Check if we should notify about this tournament
 			if notificationState.ShouldNotify(t.TournamentID, t.AvailablePlaces) {
 				log.Printf("📢 Found new tournament '%s', sending notification", t.Name)
-				formatTournament(&sb, t)
+				formatTournament(&sb, t, cfg.Friends)
 			} else {
 				log.Printf("✓ Tournament '%s' already in state, skipping notification", t.Name)
 			}
@@ -173,10 +219,16 @@ func run() int {
 			client.WithBaseURL(client.DefaultBaseUrlV1),
 			client.WithRefreshToken(*refreshToken),
 			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
 		)
 		activeClient = v1Client
+		if err := resolveMe(ctx, v1Client, cfg); err != nil {
+			log.Printf("Failed to identify user: %v", err)
+			return 1
+		}
 
 		var matchedClasses []models.Class
+		var matchedCourses []courseMatch
 		for _, cf := range cfg.Classes {
 			classes, err := fetchClasses(ctx, v1Client, cf)
 			if err != nil {
@@ -185,10 +237,20 @@ func run() int {
 				continue
 			}
 
-			matchedClasses = append(matchedClasses, filter.ApplyClasses(classes, cf)...)
+			if *explain {
+				writeExplain(os.Stdout, "classes at "+cfg.TenantName(cf.TenantID), filter.ExplainClasses(classes, cf))
+			}
+			matched := filter.ApplyClasses(classes, cf)
+			if cf.GroupByCourse {
+				courses, loose := groupMatchedCourses(classes, matched)
+				matchedCourses = append(matchedCourses, courses...)
+				matchedClasses = append(matchedClasses, loose...)
+				continue
+			}
+			matchedClasses = append(matchedClasses, matched...)
 		}
 
-		if len(matchedClasses) == 0 {
+		if len(matchedClasses) == 0 && len(matchedCourses) == 0 {
 			fmt.Println("No matching classes found.")
 			if hadErrors {
 				return 1
@@ -196,25 +258,20 @@ func run() int {
 			return 0
 		}
 
-		for _, c := range matchedClasses {
-			printClass(c)
+		if origin, ok := cfg.RankOrigin(); ok {
+			models.SortByDistance(matchedClasses, origin)
+		}
 
-			// Calculate available places for classes
-			availablePlaces := 0
-			if c.CourseSummary != nil {
-				availablePlaces = c.CourseSummary.MaxPlayers - len(c.RegistrationInfo.Registrations)
-			}
+		for _, c := range matchedClasses {
+			printClass(c, cfg.Friends)
 
-			// Get class name for logging
-			className := "Unknown"
-			if c.CourseSummary != nil {
-				className = c.CourseSummary.Name
-			}
+			availablePlaces := c.FreePlaces()
+			className := c.Title()
 
 			// Check if we should notify about this class
 			if notificationState.ShouldNotify(c.AcademyClassID, availablePlaces) {
 				log.Printf("📢 Found new class '%s', sending notification", className)
-				formatClass(&sb, c)
+				formatClass(&sb, c, cfg.Friends)
 			} else {
 				log.Printf("✓ Class '%s' already in state, skipping notification", className)
 			}
@@ -223,6 +280,24 @@ func run() int {
 			notificationState.Update(c.AcademyClassID, availablePlaces)
 		}
 
+		for _, cm := range matchedCourses {
+			printCourse(cm)
+
+			// Keyed per course, with the number of sessions that have a free
+			// seat standing in for available places: a session freeing up
+			// re-notifies, the same way a dropout does for a single class.
+			key := "course:" + cm.Course.CourseID
+			freeSessions := len(cm.FreeSessions)
+			if notificationState.ShouldNotify(key, freeSessions) {
+				log.Printf("📢 Found course '%s' with free seats, sending notification", cm.Course.Name)
+				formatCourse(&sb, cm)
+			} else {
+				log.Printf("✓ Course '%s' already in state, skipping notification", cm.Course.Name)
+			}
+
+			notificationState.Update(key, freeSessions)
+		}
+
 	case "courts":
 		if len(cfg.Courts) == 0 {
 			log.Fatalf("No court filters configured in %s", *configPath)
@@ -243,24 +318,30 @@ func run() int {
 			client.WithBaseURL(client.DefaultBaseUrlV1),
 			client.WithRefreshToken(*refreshToken),
 			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
 		)
 		activeClient = v1Client
 
-		berlinLoc, err := time.LoadLocation("Europe/Berlin")
-		if err != nil {
-			log.Fatalf("Failed to load Europe/Berlin timezone: %v", err)
-		}
-
 		var totalMatched int
-		now := time.Now().UTC()
+		now := time.Now()
 
 		for _, cf := range cfg.Courts {
-			clubName := tenantName(cf.TenantID)
+			clubName := cfg.TenantName(cf.TenantID)
 			var clubMatches int
 
-			for day := 0; day <= 14; day++ {
-				date := now.AddDate(0, 0, day)
-				availability, err := fetchCourtAvailability(ctx, v1Client, cf, date)
+			loc, err := courtLocation(ctx, v1Client, cfg.CourtTimezone(cf), cf.TenantID)
+			if err != nil {
+				log.Printf("Error resolving timezone for tenant %s: %v", cf.TenantID, err)
+				hadErrors = true
+				continue
+			}
+
+			for day := 0; day <= cf.Lookahead(); day++ {
+				date := now.In(loc).AddDate(0, 0, day)
+				if !cf.IncludesDate(date.Format(models.DateFormat)) {
+					continue
+				}
+				availability, err := fetchCourtAvailability(ctx, v1Client, cf, date, loc)
 				if err != nil {
 					log.Printf("Error fetching courts for tenant %s on %s: %v",
 						cf.TenantID, date.Format("2006-01-02"), err)
@@ -268,15 +349,44 @@ func run() int {
 					continue
 				}
 
-				matched := filter.ApplyCourts(availability, cf)
+				if *explain {
+					title := fmt.Sprintf("courts at %s on %s", clubName, date.Format(models.DateFormat))
+					if cf.IsGroupSearch() {
+						writeExplain(os.Stdout, title, filter.ExplainCourtGroups(availability, cf, loc))
+					} else {
+						writeExplain(os.Stdout, title, filter.ExplainCourts(availability, cf, loc))
+					}
+				}
+
+				if cf.IsGroupSearch() {
+					for _, g := range filter.ApplyCourtGroups(availability, cf, loc) {
+						printCourtGroup(clubName, g)
+
+						// One state entry per combination, with the number of
+						// courts that can host it standing in for places.
+						key := courtGroupKey(cf, g)
+						if courtState.ShouldNotify(key, len(g.Courts)) {
+							log.Printf("📢 New court group at %s, sending notification", formatLocalTime(g.Start))
+							formatCourtGroup(&sb, clubName, cf, g)
+						} else {
+							log.Printf("✓ Court group at %s already in state, skipping notification", formatLocalTime(g.Start))
+						}
+						courtState.Update(key, len(g.Courts))
+						clubMatches++
+						totalMatched++
+					}
+					continue
+				}
+
+				matched := filter.ApplyCourts(availability, cf, loc)
 				for _, court := range matched {
 					for _, slot := range court.Slots {
-						printCourtSlot(clubName, court, slot, berlinLoc)
+						printCourtSlot(clubName, court, slot, loc)
 
-						slotKey := court.ResourceID + "|" + court.StartDate + "|" + slot.StartTime
+						slotKey := court.ResourceID + "|" + court.StartDate.String() + "|" + slot.StartTime.String()
 						if courtState.ShouldNotify(slotKey, 1) {
 							log.Printf("📢 New court slot %s at %s, sending notification", court.ResourceID, slot.StartTime)
-							formatCourtSlot(&sb, clubName, court, slot, berlinLoc)
+							formatCourtSlot(&sb, clubName, court, slot, loc)
 						} else {
 							log.Printf("✓ Court slot %s at %s already in state, skipping notification", court.ResourceID, slot.StartTime)
 						}
@@ -299,6 +409,245 @@ func run() int {
 			}
 			return 0
 		}
+
+	case "matches":
+		if len(cfg.Matches) == 0 {
+			log.Fatalf("No match filters configured in %s", *configPath)
+		}
+
+		matchState := state.New(*matchStatePath)
+		if err := matchState.Load(); err != nil {
+			log.Fatalf("Failed to load match state: %v", err)
+		}
+		defer func() {
+			if err := matchState.Save(); err != nil {
+				log.Printf("Failed to save match state: %v", err)
+			}
+		}()
+
+		v1Client := client.NewClient(
+			client.WithTimeout(*timeout),
+			client.WithBaseURL(client.DefaultBaseUrlV1),
+			client.WithRefreshToken(*refreshToken),
+			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
+		)
+		activeClient = v1Client
+		if err := resolveMe(ctx, v1Client, cfg); err != nil {
+			log.Printf("Failed to identify user: %v", err)
+			return 1
+		}
+
+		var matchedMatches []models.Match
+		for _, mf := range cfg.Matches {
+			matches, err := fetchMatches(ctx, v1Client, mf)
+			if err != nil {
+				log.Printf("Error fetching matches for tenants %s: %v", tenantNames(cfg, mf.TenantIDs), err)
+				hadErrors = true
+				continue
+			}
+
+			if *explain {
+				writeExplain(os.Stdout, "matches at "+tenantNames(cfg, mf.TenantIDs), filter.ExplainMatches(matches, mf))
+			}
+			matchedMatches = append(matchedMatches, filter.ApplyMatches(matches, mf)...)
+		}
+
+		if len(matchedMatches) == 0 {
+			fmt.Println("No matching matches found.")
+			if hadErrors {
+				return 1
+			}
+			return 0
+		}
+
+		if origin, ok := cfg.RankOrigin(); ok {
+			models.SortByDistance(matchedMatches, origin)
+		}
+
+		for _, m := range matchedMatches {
+			printMatch(m, clubDisplayName(cfg, m.Tenant), cfg.Friends)
+
+			// Keyed on free positions, so a match re-notifies when a spot
+			// opens up again after filling.
+			if matchState.ShouldNotify(m.MatchID, m.FreePositions()) {
+				log.Printf("📢 Found new match %s at %s, sending notification", m.MatchID, formatLocalTime(m.Start()))
+				formatMatch(&sb, m, clubDisplayName(cfg, m.Tenant), cfg.Friends)
+			} else {
+				log.Printf("✓ Match %s already in state, skipping notification", m.MatchID)
+			}
+			matchState.Update(m.MatchID, m.FreePositions())
+		}
+
+	case "lessons":
+		if len(cfg.Lessons) == 0 {
+			log.Fatalf("No lesson filters configured in %s", *configPath)
+		}
+
+		lessonState := state.New(*lessonStatePath)
+		if err := lessonState.Load(); err != nil {
+			log.Fatalf("Failed to load lesson state: %v", err)
+		}
+		defer func() {
+			if err := lessonState.Save(); err != nil {
+				log.Printf("Failed to save lesson state: %v", err)
+			}
+		}()
+
+		// Lessons are served by the same API version as tournaments.
+		v2Client := client.NewClient(
+			client.WithTimeout(*timeout),
+			client.WithBaseURL(client.DefaultBaseUrlV2),
+			client.WithRefreshToken(*refreshToken),
+			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
+		)
+		activeClient = v2Client
+		if err := resolveMe(ctx, v2Client, cfg); err != nil {
+			log.Printf("Failed to identify user: %v", err)
+			return 1
+		}
+
+		var totalMatched int
+		for _, lf := range cfg.Lessons {
+			lessons, err := fetchLessons(ctx, v2Client, lf)
+			if err != nil {
+				log.Printf("Error fetching lessons for tenant %s: %v", lf.TenantID, err)
+				hadErrors = true
+				continue
+			}
+
+			if *explain {
+				writeExplain(os.Stdout, "lessons at "+cfg.TenantName(lf.TenantID), filter.ExplainLessons(lessons, lf))
+			}
+			matched := filter.ApplyLessons(lessons, lf)
+			if origin, ok := cfg.RankOrigin(); ok {
+				models.SortByDistance(matched, origin)
+			}
+
+			for _, l := range matched {
+				club := clubDisplayName(cfg, models.LessonTenantToTenant(&l.Tenant))
+				printLesson(l, club, cfg.Friends)
+				totalMatched++
+
+				if lessonState.ShouldNotify(l.TournamentID, l.AvailablePlaces) {
+					log.Printf("📢 Found new lesson '%s', sending notification", l.TournamentName)
+					formatLesson(&sb, l, club, cfg.Friends)
+				} else {
+					log.Printf("✓ Lesson '%s' already in state, skipping notification", l.TournamentName)
+				}
+				lessonState.Update(l.TournamentID, l.AvailablePlaces)
+
+				// A second, one-off reminder under its own key once
+				// registration is about to close.
+				if filter.RegistrationClosingSoon(l, lf.ClosingWithin) {
+					key := "closing|" + l.TournamentID
+					if lessonState.ShouldNotify(key, 1) {
+						log.Printf("📢 Registration for lesson '%s' closes soon, sending notification", l.TournamentName)
+						formatLessonClosing(&sb, l)
+					}
+					lessonState.Update(key, 1)
+				}
+			}
+		}
+
+		if totalMatched == 0 {
+			fmt.Println("No matching lessons found.")
+			if hadErrors {
+				return 1
+			}
+			return 0
+		}
+
+	case "coaches":
+		if len(cfg.Coaches) == 0 {
+			log.Fatalf("No coach filters configured in %s", *configPath)
+		}
+
+		v1Client := client.NewClient(
+			client.WithTimeout(*timeout),
+			client.WithBaseURL(client.DefaultBaseUrlV1),
+			client.WithRefreshToken(*refreshToken),
+			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
+		)
+		activeClient = v1Client
+		// Lessons are served by API v2; the derived client shares v1Client's
+		// tokens, so rotated ones are still exported.
+		lessonsClient := v1Client.WithVersion(client.DefaultBaseUrlV2)
+
+		from := models.FormatTime(time.Now().UTC())
+		for _, cf := range cfg.Coaches {
+			schedules, err := client.GetCoachSchedules(ctx, v1Client, lessonsClient, cf.TenantID, from)
+			if err != nil {
+				log.Printf("Error fetching coaches for tenant %s: %v", cf.TenantID, err)
+				hadErrors = true
+				continue
+			}
+
+			schedules = filter.ApplyCoaches(schedules, cf)
+			if len(schedules) == 0 {
+				fmt.Printf("No coaches found for %s.\n", cfg.TenantName(cf.TenantID))
+				continue
+			}
+			for _, s := range schedules {
+				printCoachSchedule(s)
+			}
+		}
+
+		// Coach schedules are informational only: nothing to notify about.
+		if hadErrors {
+			return 1
+		}
+		return 0
+
+	case "payments":
+		fs := flag.NewFlagSet("payments", flag.ExitOnError)
+		// -from and -to aren't sent to the API: every payment is fetched and
+		// the range is applied here.
+		from := fs.String("from", "", "only include payments on or after this date (YYYY-MM-DD), filtered client-side")
+		to := fs.String("to", "", "only include payments before this date (YYYY-MM-DD), filtered client-side")
+		output := fs.String("output", "", "write CSV to this file instead of stdout")
+		fs.Parse(args[1:])
+
+		fromDate, err := parseDateFlag(*from)
+		if err != nil {
+			log.Fatalf("Invalid -from: %v", err)
+		}
+		toDate, err := parseDateFlag(*to)
+		if err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+
+		v1Client := client.NewClient(
+			client.WithTimeout(*timeout),
+			client.WithBaseURL(client.DefaultBaseUrlV1),
+			client.WithRefreshToken(*refreshToken),
+			client.WithAccessToken(*accessToken),
+			client.WithDriftDetection(*drift != ""),
+		)
+		activeClient = v1Client
+
+		payments, err := v1Client.GetPayments(ctx, &models.SearchPaymentsParams{})
+		if errors.Is(err, client.ErrTruncated) {
+			log.Printf("Warning: %v; older payments may be missing", err)
+		} else if err != nil {
+			log.Printf("Error fetching payments: %v", err)
+			return 1
+		}
+		payments = models.FilterPaymentsByDate(payments, fromDate, toDate)
+
+		if *output == "" {
+			err = writePaymentsCSV(os.Stdout, payments)
+		} else {
+			err = writePaymentsFile(*output, payments)
+		}
+		if err != nil {
+			log.Printf("Failed to write payments CSV: %v", err)
+			return 1
+		}
+		log.Printf("Exported %d payments", len(payments))
+		return 0
 	}
 
 	if sb.Len() > 0 {
@@ -355,28 +704,36 @@ func exportRotatedToken(envVarName, original, current string) {
 	log.Printf("%s changed; exported for this job.", envVarName)
 }
 
-// tenantNames maps known tenant IDs to human-readable club names.
-var tenantNames = map[string]string{
-	"8b818dae-aacb-4ea3-aa7b-0e77b1149c85": "Charlotte-Mitte",
-	"9fea856e-7d1a-4cae-9831-79015318967b": "PBC",
-	"4a3497a5-f9bd-43eb-9aaa-a972a856b3d2": "PadelBros",
-	"041a4a3c-8895-465d-91d1-c22f75049770": "TioTio",
-	"aa7e1831-a90d-4a4f-b6ae-f6d334179907": "Beach Mitte",
+// clubDisplayName returns the name the config gives a club (see
+// config.Config.TenantName), falling back to the API's name for clubs the
+// config doesn't list.
+func clubDisplayName(cfg *config.Config, t models.Tenant) string {
+	if name := cfg.TenantName(t.TenantID); name != t.TenantID || t.TenantName == "" {
+		return name
+	}
+	return t.TenantName
 }
 
-func tenantName(id string) string {
-	if name, ok := tenantNames[id]; ok {
-		return name
+// tenantNames lists clubs by their configured names.
+func tenantNames(cfg *config.Config, ids []string) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = cfg.TenantName(id)
 	}
-	return id
+	return strings.Join(names, ", ")
 }
 
 func printUsage() {
-	fmt.Println("Usage: playtomic-watch [OPTIONS] <tournaments|classes|courts>")
+	fmt.Println("Usage: playtomic-watch [OPTIONS] <tournaments|classes|courts|coaches|matches|lessons|payments>")
 	fmt.Println("\nSubcommands:")
 	fmt.Println("  tournaments    Search for tournaments")
 	fmt.Println("  classes        Search for classes")
 	fmt.Println("  courts         Search for available courts")
+	fmt.Println("  coaches        Print upcoming coach schedules")
+	fmt.Println("  matches        Search for open matches you could join")
+	fmt.Println("  lessons        Search for lessons")
+	fmt.Println("  payments       Export your payments and refunds as CSV")
+	fmt.Println("                 (payments [-from YYYY-MM-DD] [-to YYYY-MM-DD] [-output FILE])")
 	fmt.Println("\nOptions:")
 	flag.PrintDefaults()
 }
@@ -393,7 +750,7 @@ func fetchTournaments(ctx context.Context, c *client.Client, tf config.Tournamen
 		params.RegistrationStatus = tf.RegistrationStatus
 	}
 	if tf.Status != "" {
-		params.Status = tf.Status
+		params.Status = tf.Statuses()
 	}
 	if tf.MinAvailablePlaces > 0 {
 		params.AvailablePlaces = true
@@ -405,20 +762,67 @@ func fetchTournaments(ctx context.Context, c *client.Client, tf config.Tournamen
 func fetchClasses(ctx context.Context, c *client.Client, cf config.ClassFilter) ([]models.Class, error) {
 	params := &models.SearchClassesParams{
 		TenantIDs: []string{cf.TenantID},
+		// Courses are identified by the class's course summary.
+		IncludeSummary: cf.GroupByCourse,
 	}
 
 	// Note: course_visibility and show_only_available are no longer accepted by
 	// the API; those filters are applied client-side in filter.ApplyClasses.
 	if cf.Status != "" {
-		params.Status = cf.Status
+		params.Status = cf.Statuses()
 	}
 	if cf.Type != "" {
-		params.Type = cf.Type
+		params.Type = cf.Types()
 	}
 
 	return c.GetClasses(ctx, params)
 }
 
+func fetchMatches(ctx context.Context, c *client.Client, mf config.MatchFilter) ([]models.Match, error) {
+	params, err := models.NewMatchSearch().
+		Tenants(mf.TenantIDs...).
+		Sport(mf.SportID).
+		Visibility(mf.Visibility).
+		HasPlayers().
+		From(time.Now().UTC()).
+		Sort("start_date,ASC").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetMatches(ctx, params)
+}
+
+func fetchLessons(ctx context.Context, c *client.Client, lf config.LessonFilter) ([]models.Lesson, error) {
+	params, err := models.NewLessonSearch().
+		Tenant(lf.TenantID).
+		Visibility(lf.Visibility).
+		Status(lf.Statuses()...).
+		From(time.Now().UTC()).
+		Sort("start_date,ASC").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetLessons(ctx, params)
+}
+
+// resolveMe fills in the configured user's ID from the access token when
+// the config asks for me.from_token, so skip_joined can compare user IDs.
+// Reading the token may exchange the refresh token, so callers return
+// rather than exit on error, letting the rotated tokens be exported.
+func resolveMe(ctx context.Context, c *client.Client, cfg *config.Config) error {
+	if !cfg.Me.FromToken {
+		return nil
+	}
+	id, err := c.UserID(ctx)
+	if err != nil {
+		return fmt.Errorf("reading user ID from access token: %w", err)
+	}
+	cfg.SetUserID(id)
+	return nil
+}
+
 func notify(bot *telegram.Bot, msg string) {
 	if bot == nil {
 		return
@@ -427,24 +831,85 @@ func notify(bot *telegram.Bot, msg string) {
 		log.Printf("Failed to send Telegram message: %v", err)
 	}
 }
-func printTournament(t models.Tournament) {
+func printTournament(t models.Tournament, friends []string) {
 	fmt.Printf("--- Tournament ---\n")
 	fmt.Printf("  ID:               %s\n", t.TournamentID)
 	fmt.Printf("  Name:             %s\n", t.Name)
 	fmt.Printf("  Status:           %s\n", t.Status)
 	fmt.Printf("  Visibility:       %s\n", t.Visibility)
 	fmt.Printf("  Available Places: %d\n", t.AvailablePlaces)
+	if names := friendNames(t, friends); names != "" {
+		fmt.Printf("  Friends:          %s\n", names)
+	}
 	fmt.Println()
 }
 
-func formatTournament(sb *strings.Builder, t models.Tournament) {
+func formatTournament(sb *strings.Builder, t models.Tournament, friends []string) {
 	fmt.Fprintf(sb, "🏆 %s\n", t.Name)
 	fmt.Fprintf(sb, "  Status: %s\n", t.Status)
 	fmt.Fprintf(sb, "  Places: %d\n", t.AvailablePlaces)
+	formatFriends(sb, t, friends)
+	sb.WriteString("\n")
+}
+
+// courseMatch is a course with at least one session that passed the class
+// filter. FreeSessions are the matching sessions that still have a free seat,
+// earliest first.
+type courseMatch struct {
+	Course       models.Course
+	FreeSessions []models.Class
+}
+
+// groupMatchedCourses groups all fetched classes into courses, and keeps the
+// courses that have at least one matched session with a free seat. All
+// fetched sessions are kept on the course so the total reflects the whole
+// programme, not just the sessions that passed the filter. Matched classes
+// that belong to no course are returned as loose, to be reported one by one.
+func groupMatchedCourses(classes, matched []models.Class) (courses []courseMatch, loose []models.Class) {
+	matchedIDs := make(map[string]bool, len(matched))
+	for _, c := range matched {
+		matchedIDs[c.AcademyClassID] = true
+		if c.CourseSummary == nil || c.CourseSummary.CourseID == "" {
+			loose = append(loose, c)
+		}
+	}
+
+	for _, course := range models.GroupCourses(classes) {
+		var free []models.Class
+		for _, s := range course.SessionsWithFreePlaces() {
+			if matchedIDs[s.AcademyClassID] {
+				free = append(free, s)
+			}
+		}
+		if len(free) > 0 {
+			slices.SortStableFunc(free, func(a, b models.Class) int { return a.Start().Compare(b.Start()) })
+			courses = append(courses, courseMatch{Course: course, FreeSessions: free})
+		}
+	}
+	return courses, loose
+}
+
+func printCourse(cm courseMatch) {
+	fmt.Printf("--- Course ---\n")
+	fmt.Printf("  ID:            %s\n", cm.Course.CourseID)
+	fmt.Printf("  Name:          %s\n", cm.Course.Name)
+	fmt.Printf("  Sessions:      %d free of %d\n", len(cm.FreeSessions), len(cm.Course.Sessions))
+	fmt.Printf("  Enrolled:      %d/%d\n", cm.Course.EnrolledCount(), cm.Course.TotalCapacity())
+	fmt.Printf("  Price:         %s\n", cm.Course.Price())
+	fmt.Println()
+}
+
+func formatCourse(sb *strings.Builder, cm courseMatch) {
+	fmt.Fprintf(sb, "🎓 %s\n", cm.Course.Name)
+	fmt.Fprintf(sb, "  Free seat in %d of %d sessions\n", len(cm.FreeSessions), len(cm.Course.Sessions))
+	fmt.Fprintf(sb, "  Next: %s\n", formatLocalTime(cm.FreeSessions[0].Start()))
+	if price := cm.Course.Price(); !price.IsZero() {
+		fmt.Fprintf(sb, "  Price: %s\n", price)
+	}
 	sb.WriteString("\n")
 }
 
-func printClass(c models.Class) {
+func printClass(c models.Class, friends []string) {
 	fmt.Printf("--- Class ---\n")
 	fmt.Printf("  ID:          %s\n", c.AcademyClassID)
 	if c.CourseSummary != nil {
@@ -465,27 +930,120 @@ func printClass(c models.Class) {
 		fmt.Println()
 	}
 	fmt.Printf("  Registrations: %d\n", len(c.RegistrationInfo.Registrations))
+	if names := friendNames(c, friends); names != "" {
+		fmt.Printf("  Friends:     %s\n", names)
+	}
+	fmt.Println()
+}
+
+func printCoachSchedule(s models.CoachSchedule) {
+	fmt.Printf("--- Coach ---\n")
+	fmt.Printf("  Name:     %s\n", s.Coach.Name)
+	fmt.Printf("  User ID:  %s\n", s.Coach.UserID)
+	fmt.Printf("  Sessions: %d\n", len(s.Sessions))
+	for _, session := range s.Sessions {
+		fmt.Printf("    %s  %-6s  %s (%s)\n", formatLocalTime(session.Start), session.Kind, session.Name, session.TenantName)
+	}
 	fmt.Println()
 }
 
-// fetchCourtAvailability queries the availability endpoint for a single day.
-// The API requires a window ≤25h, so we use prev-day 22:00 UTC to curr-day 21:59:59 UTC,
-// which maps to exactly one midnight-to-midnight window in Europe/Berlin (CET/CEST).
-func fetchCourtAvailability(ctx context.Context, c *client.Client, cf config.CourtFilter, day time.Time) ([]models.CourtAvailability, error) {
-	startMin := time.Date(day.Year(), day.Month(), day.Day()-1, 22, 0, 0, 0, time.UTC)
-	startMax := time.Date(day.Year(), day.Month(), day.Day(), 21, 59, 59, 0, time.UTC)
+// parseDateFlag parses an optional YYYY-MM-DD flag value as a UTC date,
+// returning the zero time when it's empty.
+func parseDateFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// writePaymentsFile writes the payments CSV to path. The file is closed
+// before returning, so a write that only fails on close is reported too.
+func writePaymentsFile(path string, payments []models.Payment) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writePaymentsCSV(f, payments); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writePaymentsCSV writes one row per payment with its amount in major
+// units. Refunds carry a negative amount so a month's rows can be summed.
+func writePaymentsCSV(w io.Writer, payments []models.Payment) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"payment_id", "date", "type", "amount", "currency", "user_vat", "tenant_vat", "payment_method", "tenant_id", "reservation_id", "academy_class_id"})
+
+	for _, p := range payments {
+		amount := p.Amount()
+		decimal := amount.Decimal()
+		if !amount.Known() {
+			decimal = amount.String() // the API's text, as it couldn't be parsed
+		}
+
+		kind := "PAYMENT"
+		if p.IsRefund() {
+			kind = "REFUND"
+		}
+
+		cw.Write([]string{
+			p.PaymentID,
+			p.PaymentDate.String(),
+			kind,
+			decimal,
+			amount.Currency,
+			strconv.FormatFloat(p.UserVat, 'f', -1, 64),
+			strconv.FormatFloat(p.TenantVat, 'f', -1, 64),
+			p.PaymentMethodType,
+			p.TenantID,
+			derefString(p.ReservationID),
+			derefString(p.AcademyClassID),
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
 
-	params := &models.SearchAvailabilityParams{
-		TenantID: cf.TenantID,
-		SportID:  cf.SportID,
-		StartMin: startMin.Format("2006-01-02T15:04:05"),
-		StartMax: startMax.Format("2006-01-02T15:04:05"),
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// fetchCourtAvailability fetches the slots starting on day's calendar date
+// in loc, local midnight to 23:59:59. The window is 23, 24 or 25 hours long
+// depending on DST, all within the API's limit.
+func fetchCourtAvailability(ctx context.Context, c *client.Client, cf config.CourtFilter, day time.Time, loc *time.Location) ([]models.CourtAvailability, error) {
+	params, err := models.NewAvailabilitySearch(cf.TenantID).Sport(cf.SportID).Day(day, loc).Build()
+	if err != nil {
+		return nil, err
 	}
 	return c.GetAvailability(ctx, params)
 }
 
+// courtLocation resolves the zone court windows are read in: the configured
+// timezone if any, else the club's own, else UTC.
+func courtLocation(ctx context.Context, c *client.Client, timezone, tenantID string) (*time.Location, error) {
+	if timezone != "" {
+		return time.LoadLocation(timezone)
+	}
+
+	tenant, err := c.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Address.Timezone == "" {
+		log.Printf("Tenant %s has no timezone, reading court windows in UTC", tenantID)
+	}
+	return tenant.Address.Location(), nil
+}
+
 func printCourtSlot(clubName string, court models.CourtAvailability, slot models.Slot, loc *time.Location) {
-	t := parseSlotTime(court.StartDate, slot.StartTime, loc)
+	t := court.SlotStart(slot, loc)
 	fmt.Printf("--- Court Available ---\n")
 	fmt.Printf("  Club:     %s\n", clubName)
 	fmt.Printf("  Court:    %s\n", court.ResourceID)
@@ -496,7 +1054,7 @@ func printCourtSlot(clubName string, court models.CourtAvailability, slot models
 }
 
 func formatCourtSlot(sb *strings.Builder, clubName string, court models.CourtAvailability, slot models.Slot, loc *time.Location) {
-	t := parseSlotTime(court.StartDate, slot.StartTime, loc)
+	t := court.SlotStart(slot, loc)
 	fmt.Fprintf(sb, "🎾 %s\n", clubName)
 	fmt.Fprintf(sb, "  Court: %s\n", court.ResourceID)
 	fmt.Fprintf(sb, "  Time: %s\n", t.Format("Mon 02 Jan 2006 15:04 MST"))
@@ -504,18 +1062,42 @@ func formatCourtSlot(sb *strings.Builder, clubName string, court models.CourtAva
 	sb.WriteString("\n")
 }
 
-// parseSlotTime combines the API date ("2006-01-02") and time ("15:04:05") strings
-// (both UTC) into a time.Time converted to the given location.
-func parseSlotTime(date, slotTime string, loc *time.Location) time.Time {
-	raw := date + "T" + slotTime + "Z"
-	t, err := time.Parse("2006-01-02T15:04:05Z", raw)
+// courtGroupKey identifies a group search result in state: the club, the
+// shared start and the search's shape, but not which courts, so courts
+// freeing up re-notify instead of creating a new entry.
+func courtGroupKey(cf config.CourtFilter, g filter.CourtGroup) string {
+	return fmt.Sprintf("group|%s|%s|%dx%d", cf.TenantID, models.FormatTimeUTC(g.Start), max(cf.CourtsNeeded, 1), cf.ConsecutiveMinutes)
+}
+
+func printCourtGroup(clubName string, g filter.CourtGroup) {
+	fmt.Printf("--- Courts Available Together ---\n")
+	fmt.Printf("  Club:     %s\n", clubName)
+	fmt.Printf("  Time:     %s\n", g.Start.Format("Mon 02 Jan 2006 15:04 MST"))
+	for _, run := range g.Courts {
+		fmt.Printf("  Court:    %s (%s)\n", run.ResourceID, formatCourtRun(run))
+	}
+	fmt.Println()
+}
+
+func formatCourtGroup(sb *strings.Builder, clubName string, cf config.CourtFilter, g filter.CourtGroup) {
+	fmt.Fprintf(sb, "🎾 %s: %d of %d courts free together\n", clubName, len(g.Courts), max(cf.CourtsNeeded, 1))
+	fmt.Fprintf(sb, "  Time: %s\n", g.Start.Format("Mon 02 Jan 2006 15:04 MST"))
+	for _, run := range g.Courts {
+		fmt.Fprintf(sb, "  Court: %s (%s)\n", run.ResourceID, formatCourtRun(run))
+	}
+	sb.WriteString("\n")
+}
+
+// formatCourtRun describes a run as "180 min, 72 EUR".
+func formatCourtRun(run filter.CourtRun) string {
+	price, err := run.Price()
 	if err != nil {
-		return time.Time{}
+		return fmt.Sprintf("%d min", run.Minutes())
 	}
-	return t.In(loc)
+	return fmt.Sprintf("%d min, %s", run.Minutes(), price)
 }
 
-func formatClass(sb *strings.Builder, c models.Class) {
+func formatClass(sb *strings.Builder, c models.Class, friends []string) {
 	if c.CourseSummary != nil {
 		fmt.Fprintf(sb, "🎓 %s\n", c.CourseSummary.Name)
 	} else {
@@ -523,38 +1105,140 @@ func formatClass(sb *strings.Builder, c models.Class) {
 	}
 	fmt.Fprintf(sb, "  Type: %s\n", c.Type)
 	fmt.Fprintf(sb, "  Status: %s\n", c.Status)
-	fmt.Fprintf(sb, "  Start: %s\n", formatBerlinTime(c.StartDate))
+	fmt.Fprintf(sb, "  Start: %s\n", formatLocalTime(c.Start()))
 	if len(c.Coaches) > 0 {
 		fmt.Fprintf(sb, "  Coach: %s\n", c.Coaches[0].Name)
 	}
 	fmt.Fprintf(sb, "  Registrations: %d\n", len(c.RegistrationInfo.Registrations))
+	formatFriends(sb, c, friends)
 	sb.WriteString("\n")
 }
 
-// formatBerlinTime converts an ISO date string to Berlin timezone format
-func formatBerlinTime(dateStr string) string {
-	// Parse the ISO 8601 date string
-	t, err := time.Parse(time.RFC3339, dateStr)
-	if err != nil {
-		// If parsing fails, try without timezone info
-		t, err = time.Parse("2006-01-02T15:04:05", dateStr)
-		if err != nil {
-			// If still fails, return original string
-			return dateStr
-		}
+func printMatch(m models.Match, club string, friends []string) {
+	fmt.Printf("--- Match ---\n")
+	fmt.Printf("  ID:             %s\n", m.MatchID)
+	fmt.Printf("  Club:           %s\n", club)
+	fmt.Printf("  Start:          %s\n", formatLocalTime(m.Start()))
+	fmt.Printf("  Level:          %.1f-%.1f\n", m.MinLevel, m.MaxLevel)
+	fmt.Printf("  Gender:         %s\n", m.Gender)
+	fmt.Printf("  Free Positions: %d\n", m.FreePositions())
+	fmt.Printf("  Price:          %s\n", m.Price)
+	if names := friendNames(m, friends); names != "" {
+		fmt.Printf("  Friends:        %s\n", names)
+	}
+	fmt.Println()
+}
+
+func formatMatch(sb *strings.Builder, m models.Match, club string, friends []string) {
+	fmt.Fprintf(sb, "🎾 Match at %s\n", club)
+	fmt.Fprintf(sb, "  Start: %s\n", formatLocalTime(m.Start()))
+	fmt.Fprintf(sb, "  Level: %.1f-%.1f | %s\n", m.MinLevel, m.MaxLevel, m.Gender)
+	fmt.Fprintf(sb, "  Free positions: %d | Price: %s\n", m.FreePositions(), m.Price)
+	formatFriends(sb, m, friends)
+	sb.WriteString("\n")
+}
+
+func printLesson(l models.Lesson, club string, friends []string) {
+	fmt.Printf("--- Lesson ---\n")
+	fmt.Printf("  ID:                 %s\n", l.TournamentID)
+	fmt.Printf("  Name:               %s\n", l.TournamentName)
+	fmt.Printf("  Club:               %s\n", club)
+	fmt.Printf("  Start:              %s\n", formatLocalTime(l.Start()))
+	fmt.Printf("  Level:              %s\n", l.LevelDescription)
+	fmt.Printf("  Price:              %s\n", l.Price)
+	fmt.Printf("  Available Places:   %d\n", l.AvailablePlaces)
+	if closes := l.RegistrationCloses(); !closes.IsZero() {
+		fmt.Printf("  Registration Until: %s\n", formatLocalTime(closes))
 	}
+	if names := friendNames(l, friends); names != "" {
+		fmt.Printf("  Friends:            %s\n", names)
+	}
+	fmt.Println()
+}
+
+func formatLesson(sb *strings.Builder, l models.Lesson, club string, friends []string) {
+	fmt.Fprintf(sb, "📚 %s\n", l.TournamentName)
+	fmt.Fprintf(sb, "  Club: %s\n", club)
+	fmt.Fprintf(sb, "  Start: %s\n", formatLocalTime(l.Start()))
+	if l.LevelDescription != "" {
+		fmt.Fprintf(sb, "  Level: %s\n", l.LevelDescription)
+	}
+	fmt.Fprintf(sb, "  Places: %d | Price: %s\n", l.AvailablePlaces, l.Price)
+	formatFriends(sb, l, friends)
+	sb.WriteString("\n")
+}
+
+func formatLessonClosing(sb *strings.Builder, l models.Lesson) {
+	fmt.Fprintf(sb, "⏰ Registration for %s closes %s\n", l.TournamentName, formatLocalTime(l.RegistrationCloses()))
+	fmt.Fprintf(sb, "  Start: %s | Places: %d\n", formatLocalTime(l.Start()), l.AvailablePlaces)
+	sb.WriteString("\n")
+}
+
+// defaultDisplayTimezone is where times are shown when neither the API nor
+// the config says.
+const defaultDisplayTimezone = "Europe/Berlin"
 
-	// Load Berlin timezone
-	berlin, err := time.LoadLocation("Europe/Berlin")
+// displayLocation is the zone formatLocalTime falls back to: the config's
+// timezone, else defaultDisplayTimezone. main sets it once the config is
+// loaded.
+var displayLocation = loadDisplayLocation("")
+
+func loadDisplayLocation(timezone string) *time.Location {
+	if timezone == "" {
+		timezone = defaultDisplayTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
 	if err != nil {
-		// Fallback to original if timezone loading fails
-		return dateStr
+		return time.UTC
 	}
+	return loc
+}
 
-	// Convert to Berlin time
-	berlinTime := t.In(berlin)
+// formatLocalTime formats t in its own location, e.g. "Mon 16 Feb, 09:00 CET".
+// Callers pass times already in the tenant's zone (see models.Class.Start);
+// times left in UTC because the API didn't send the tenant's zone are shown
+// in displayLocation instead.
+func formatLocalTime(t time.Time) string {
+	if t.Location() == time.UTC {
+		t = t.In(displayLocation)
+	}
+	return t.Format("Mon 02 Jan, 15:04 MST")
+}
 
-	// Format as: Mon 16 Feb, 09:00 CET
-	// The timezone name will automatically be CET or CEST depending on DST
-	return berlinTime.Format("Mon 02 Jan, 15:04 MST")
+// writeExplain prints a table of filter verdicts for -explain: whether each
+// item was kept, and the rules it passed or the one that dropped it and why.
+func writeExplain(w io.Writer, title string, verdicts []filter.Verdict) {
+	fmt.Fprintf(w, "--- Explain: %s (%d fetched) ---\n", title, len(verdicts))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESULT\tID\tNAME\tRULES\tREASON")
+	for _, v := range verdicts {
+		result, reason := "kept", "passed every rule"
+		if !v.Kept {
+			result, reason = "dropped", v.FailedRule+": "+v.Reason
+		}
+		rules := strings.Join(v.Rules, ",")
+		if rules == "" {
+			rules = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", result, v.ID, v.Name, rules, reason)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+}
+
+// friendNames lists the configured friends registered for e, or "" if
+// there are none.
+func friendNames(e models.Event, friends []string) string {
+	var names []string
+	for _, p := range filter.Friends(e.Participants(), friends) {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatFriends highlights an event friends are registered for.
+func formatFriends(sb *strings.Builder, e models.Event, friends []string) {
+	if names := friendNames(e, friends); names != "" {
+		fmt.Fprintf(sb, "  👥 Friends: %s\n", names)
+	}
 }
@@ -12,7 +12,10 @@ import (
 	"time"
 
 	"github.com/rafa-garcia/go-playtomic-api/client"
+	"github.com/rafa-garcia/go-playtomic-api/internal/config"
+	"github.com/rafa-garcia/go-playtomic-api/internal/filter"
 	"github.com/rafa-garcia/go-playtomic-api/models"
+	"github.com/rafa-garcia/go-playtomic-api/models/fixtures"
 )
 
 func TestExportRotatedTokenWritesWhenChanged(t *testing.T) {
@@ -114,3 +117,198 @@ func TestRotatedTokensExportEndToEnd(t *testing.T) {
 		t.Errorf("expected the rotated refresh token to be exported, got %q", data)
 	}
 }
+
+func TestGroupMatchedCourses(t *testing.T) {
+	session := func(id string) *fixtures.ClassBuilder {
+		return fixtures.Class().WithID(id).InCourse("course-1", "Beginner").WithMaxPlayers(2)
+	}
+	classes := []models.Class{
+		session("s1").Build(),
+		session("s2").WithRegistrations(2).Build(),
+		session("s3").Build(),
+	}
+	// s3 was dropped by the filter (e.g. a blacklisted coach), s2 is full.
+	matched := []models.Class{classes[0], classes[1]}
+
+	result, loose := groupMatchedCourses(classes, matched)
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 course, got %d", len(result))
+	}
+	if len(result[0].Course.Sessions) != 3 {
+		t.Errorf("expected all 3 sessions on the course, got %d", len(result[0].Course.Sessions))
+	}
+	if len(result[0].FreeSessions) != 1 || result[0].FreeSessions[0].AcademyClassID != "s1" {
+		t.Errorf("expected only s1 as a free matched session, got %+v", result[0].FreeSessions)
+	}
+	if len(loose) != 0 {
+		t.Errorf("expected no loose classes, got %+v", loose)
+	}
+}
+
+func TestGroupMatchedCoursesKeepsClassesWithoutCourse(t *testing.T) {
+	classes := []models.Class{
+		fixtures.Class().WithID("s1").InCourse("course-1", "Beginner").WithMaxPlayers(2).Build(),
+		fixtures.Class().WithID("open-1").WithoutCourse().WithMaxPlayers(4).Build(),
+		fixtures.Class().WithID("open-2").WithoutCourse().WithMaxPlayers(4).Build(),
+	}
+	// open-2 was dropped by the filter.
+	matched := []models.Class{classes[0], classes[1]}
+
+	courses, loose := groupMatchedCourses(classes, matched)
+
+	if len(courses) != 1 || courses[0].Course.CourseID != "course-1" {
+		t.Errorf("expected course-1, got %+v", courses)
+	}
+	if len(loose) != 1 || loose[0].AcademyClassID != "open-1" {
+		t.Errorf("expected open-1 reported on its own, got %+v", loose)
+	}
+}
+
+func TestGroupMatchedCoursesEarliestSessionFirst(t *testing.T) {
+	monday := time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC)
+	session := func(id string, start time.Time) models.Class {
+		return fixtures.Class().WithID(id).InCourse("course-1", "Beginner").WithMaxPlayers(2).Starting(start).Build()
+	}
+	// The API doesn't return sessions in start order.
+	classes := []models.Class{
+		session("s1", monday.AddDate(0, 0, 14)),
+		session("s2", monday),
+		session("s3", monday.AddDate(0, 0, 7)),
+	}
+
+	result, _ := groupMatchedCourses(classes, classes)
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 course, got %d", len(result))
+	}
+	var got []string
+	for _, s := range result[0].FreeSessions {
+		got = append(got, s.AcademyClassID)
+	}
+	if want := []string{"s2", "s3", "s1"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected free sessions %v, got %v", want, got)
+	}
+}
+
+func TestWritePaymentsCSV(t *testing.T) {
+	refundID := "refund-1"
+	reservationID := "reservation-1"
+	payments := []models.Payment{
+		{PaymentID: "p1", PaymentDate: models.MustParseTime("2026-03-02T18:00:00"), PaymentPrice: models.MustParseMoney("36 EUR"), UserVat: 19, PaymentMethodType: "CREDIT_CARD", ReservationID: &reservationID},
+		{PaymentID: "p2", PaymentDate: models.MustParseTime("2026-03-05T10:00:00"), PaymentPrice: models.MustParseMoney("12.5 EUR"), RefundID: &refundID},
+	}
+
+	var sb strings.Builder
+	if err := writePaymentsCSV(&sb, payments); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines: %q", len(lines), sb.String())
+	}
+	if lines[1] != "p1,2026-03-02T18:00:00,PAYMENT,36.00,EUR,19,0,CREDIT_CARD,,reservation-1," {
+		t.Errorf("unexpected payment row: %q", lines[1])
+	}
+	if lines[2] != "p2,2026-03-05T10:00:00,REFUND,-12.50,EUR,0,0,,,," {
+		t.Errorf("unexpected refund row: %q", lines[2])
+	}
+}
+
+func TestWritePaymentsFile(t *testing.T) {
+	payments := []models.Payment{{PaymentID: "payment-1", PaymentPrice: models.MustParseMoney("12.5 EUR")}}
+
+	path := filepath.Join(t.TempDir(), "payments.csv")
+	if err := writePaymentsFile(path, payments); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if !strings.Contains(string(data), "payment-1") {
+		t.Errorf("expected the payment in the file, got %q", data)
+	}
+
+	if err := writePaymentsFile(filepath.Join(t.TempDir(), "missing", "payments.csv"), payments); err == nil {
+		t.Error("expected an error creating the file, got nil")
+	}
+	if _, err := os.Stat("/dev/full"); err == nil {
+		if err := writePaymentsFile("/dev/full", payments); err == nil {
+			t.Error("expected a write error on a full device, got nil")
+		}
+	}
+}
+
+func TestCourtGroupNotification(t *testing.T) {
+	start := time.Date(2026, 4, 10, 19, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	g := filter.CourtGroup{
+		Start: start,
+		Courts: []filter.CourtRun{
+			{ResourceID: "c1", Slots: []models.Slot{{Duration: 90, Price: models.MustParseMoney("36 EUR")}, {Duration: 90, Price: models.MustParseMoney("36 EUR")}}},
+			{ResourceID: "c2", Slots: []models.Slot{{Duration: 180, Price: models.MustParseMoney("70 EUR")}}},
+		},
+	}
+	cf := config.CourtFilter{TenantID: "t1", CourtsNeeded: 2, ConsecutiveMinutes: 180}
+
+	if got := courtGroupKey(cf, g); got != "group|t1|2026-04-10T17:00:00|2x180" {
+		t.Errorf("unexpected key %q", got)
+	}
+
+	var sb strings.Builder
+	formatCourtGroup(&sb, "Club", cf, g)
+	for _, want := range []string{"2 of 2 courts free together", "c1 (180 min, 72 EUR)", "c2 (180 min, 70 EUR)"} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("expected %q in %q", want, sb.String())
+		}
+	}
+}
+
+func TestWriteExplain(t *testing.T) {
+	verdicts := []filter.Verdict{
+		{ID: "m1", Name: "Open match", Kept: true, Rules: []string{"registration_status", "level"}},
+		{ID: "m2", Name: "Closed match", Rules: []string{"registration_status"}, FailedRule: "registration_status", Reason: "registration closed"},
+	}
+
+	var sb strings.Builder
+	writeExplain(&sb, "matches at t1", verdicts)
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a title, header and 2 rows, got %q", sb.String())
+	}
+	if lines[0] != "--- Explain: matches at t1 (2 fetched) ---" {
+		t.Errorf("unexpected title: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "kept") || !strings.Contains(lines[2], "registration_status,level") || !strings.HasSuffix(lines[2], "passed every rule") {
+		t.Errorf("unexpected kept row: %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "dropped") || !strings.HasSuffix(lines[3], "registration_status: registration closed") {
+		t.Errorf("unexpected dropped row: %q", lines[3])
+	}
+}
+
+func TestFormatLocalTime(t *testing.T) {
+	start := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+
+	// Without the tenant's zone, times are shown in the fallback zone.
+	if got := formatLocalTime(start); got != "Mon 16 Feb, 09:00 CET" {
+		t.Errorf("expected Berlin time by default, got %q", got)
+	}
+
+	defer func(loc *time.Location) { displayLocation = loc }(displayLocation)
+	displayLocation = loadDisplayLocation("Europe/London")
+	if got := formatLocalTime(start); got != "Mon 16 Feb, 08:00 GMT" {
+		t.Errorf("expected the configured zone, got %q", got)
+	}
+
+	// Times already in the tenant's zone are shown as they are.
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatalf("loading zone: %v", err)
+	}
+	if got := formatLocalTime(start.In(madrid)); got != "Mon 16 Feb, 09:00 CET" {
+		t.Errorf("expected the tenant's own zone, got %q", got)
+	}
+}
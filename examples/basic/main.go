@@ -42,8 +42,8 @@ func searchClasses(ctx context.Context, c *client.Client) ([]models.Class, error
 	// Build search parameters
 	classParams := &models.SearchClassesParams{
 		Sort:           "start_date,ASC",
-		Status:         "PENDING,IN_PROGRESS",
-		Type:           "COURSE,PUBLIC",
+		Status:         []models.ClassStatus{models.ClassStatusPending, models.ClassStatusInProgress},
+		Type:           []models.ClassType{models.ClassTypeCourse, models.ClassTypePublic},
 		IncludeSummary: true,
 		Size:           50,
 		Page:           0,
@@ -62,10 +62,10 @@ func searchClasses(ctx context.Context, c *client.Client) ([]models.Class, error
 func searchTournaments(ctx context.Context, c *client.Client) ([]models.Tournament, error) {
 	tournamentParams := &models.SearchTournamentsParams{
 		AvailablePlaces:    true,
-		RegistrationStatus: "OPEN",
-		Status:             "PENDING",
+		RegistrationStatus: models.RegistrationStatusOpen,
+		Status:             []models.TournamentStatus{models.TournamentStatusPending},
 		TenantID:           "8b818dae-aacb-4ea3-aa7b-0e77b1149c85",
-		Visibility:         "PUBLIC",
+		Visibility:         models.VisibilityPublic,
 	}
 
 	return c.GetTournaments(ctx, tournamentParams)
@@ -77,8 +77,8 @@ func searchMatches(ctx context.Context, c *client.Client) ([]models.Match, error
 	matchParams := &models.SearchMatchesParams{
 		Sort:          "start_date,DESC",
 		HasPlayers:    true,
-		SportID:       "PADEL",
-		Visibility:    "VISIBLE",
+		SportID:       models.SportPadel,
+		Visibility:    models.VisibilityVisible,
 		FromStartDate: time.Now().Format("2006-01-02") + "T00:00:00",
 		Size:          100,
 		Page:          0,
@@ -97,9 +97,13 @@ func searchMatches(ctx context.Context, c *client.Client) ([]models.Match, error
 func searchLessons(ctx context.Context, c *client.Client) ([]models.Lesson, error) {
 	// Build search parameters
 	lessonParams := &models.SearchLessonsParams{
-		Sort:                 "start_date,ASC",
-		Status:               "REGISTRATION_OPEN,REGISTRATION_CLOSED,IN_PROGRESS",
-		TournamentVisibility: "PUBLIC",
+		Sort: "start_date,ASC",
+		Status: []models.TournamentStatus{
+			models.TournamentStatusRegistrationOpen,
+			models.TournamentStatusRegistrationClosed,
+			models.TournamentStatusInProgress,
+		},
+		TournamentVisibility: models.VisibilityPublic,
 		Size:                 100,
 		Page:                 0,
 		FromStartDate:        time.Now().Format("2006-01-02") + "T00:00:00",
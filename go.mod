@@ -2,4 +2,7 @@ module github.com/rafa-garcia/go-playtomic-api
 
 go 1.24.2
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require (
+	golang.org/x/text v0.34.0
+	gopkg.in/yaml.v3 v3.0.1
+)
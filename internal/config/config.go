@@ -1,41 +1,215 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/rafa-garcia/go-playtomic-api/internal/expr"
+	"github.com/rafa-garcia/go-playtomic-api/models"
 )
 
 type Config struct {
 	Tournaments []TournamentFilter `yaml:"tournaments"`
 	Classes     []ClassFilter      `yaml:"classes"`
 	Courts      []CourtFilter      `yaml:"courts"`
+	Coaches     []CoachFilter      `yaml:"coaches"`
+	Matches     []MatchFilter      `yaml:"matches"`
+	Lessons     []LessonFilter     `yaml:"lessons"`
+
+	// Locations names reference points such as "home" and "work" for
+	// filters' near and for RankBy.
+	Locations map[string]models.Coordinate `yaml:"locations"`
+	// RankBy, if set, names a location to sort matched tournaments,
+	// classes, matches and lessons by, nearest club first.
+	RankBy string `yaml:"rank_by"`
+	// Timezone is the IANA zone court time windows and ignored_days are
+	// read in, unless a filter sets its own. Empty means each club's own
+	// zone.
+	Timezone string `yaml:"timezone"`
+
+	// Me identifies the user running the watcher. Friends are other
+	// players' user IDs; events they're registered for are highlighted.
+	Me      Me       `yaml:"me"`
+	Friends []string `yaml:"friends"`
+
+	// Tenants names clubs by alias, so filters can say tenant: charlotte
+	// instead of a tenant ID.
+	Tenants map[string]Tenant `yaml:"tenants"`
+}
+
+// Tenant is a club filters can refer to by its alias in Config.Tenants.
+type Tenant struct {
+	ID       string `yaml:"id"`
+	Name     string `yaml:"name"`     // display name; defaults to the alias
+	Timezone string `yaml:"timezone"` // court searches' zone, unless a filter sets its own
+}
+
+// Me identifies a user by Playtomic user ID, which unlike a display name
+// survives renames and tells namesakes apart.
+type Me struct {
+	UserID string `yaml:"user_id"`
+	// FromToken takes the user ID from the access token's subject at run
+	// time instead (see SetUserID).
+	FromToken bool `yaml:"from_token"`
+}
+
+// Identity skips events the configured user (Config.Me) has already joined.
+type Identity struct {
+	SkipJoined bool `yaml:"skip_joined"`
+
+	// UserID is Config.Me's user ID, filled in by Load or SetUserID.
+	UserID string `yaml:"-"`
+}
+
+// Proximity restricts a filter to clubs near one of Config.Locations.
+type Proximity struct {
+	Near          string  `yaml:"near"`            // a key of Config.Locations
+	MaxDistanceKm float64 `yaml:"max_distance_km"` // 0 means no limit
+
+	// Origin is Near resolved by Load.
+	Origin *models.Coordinate `yaml:"-"`
+}
+
+// Allows reports whether a club at c is within MaxDistanceKm of Origin.
+// Clubs without a known coordinate are allowed rather than guessed about.
+func (p Proximity) Allows(c models.Coordinate) bool {
+	if p.Origin == nil || p.MaxDistanceKm <= 0 || c.IsZero() {
+		return true
+	}
+	return p.Origin.Within(c, p.MaxDistanceKm*1000)
 }
 
 type TournamentFilter struct {
-	TenantID           string   `yaml:"tenant_id"`
-	Visibility         string   `yaml:"visibility"`
-	RegistrationStatus string   `yaml:"registration_status"`
-	Status             string   `yaml:"status"`
-	MinAvailablePlaces int      `yaml:"min_available_places"`
-	Blacklist          []string `yaml:"blacklist"`
-	PlayerName         string   `yaml:"player_name"`
+	TenantID           string                    `yaml:"tenant_id"`
+	Tenant             string                    `yaml:"tenant"` // alias from Config.Tenants, instead of tenant_id
+	Visibility         models.Visibility         `yaml:"visibility"`
+	RegistrationStatus models.RegistrationStatus `yaml:"registration_status"`
+	Status             string                    `yaml:"status"` // comma-separated, e.g. "PENDING,IN_PROGRESS"
+	MinAvailablePlaces int                       `yaml:"min_available_places"`
+	Blacklist          []string                  `yaml:"blacklist"`
+	BlacklistMode      MatchMode                 `yaml:"blacklist_mode"` // default literal
+	PlayerName         string                    `yaml:"player_name"`
+	PlayerNameMode     MatchMode                 `yaml:"player_name_mode"` // default word
+	Proximity          `yaml:",inline"`
+	Identity           `yaml:",inline"`
+
+	// Where is an optional expression over expr.Tournaments, ANDed with
+	// the fields above. WhereExpr is Where compiled by Load.
+	Where     string                           `yaml:"where"`
+	WhereExpr *expr.Program[models.Tournament] `yaml:"-"`
+}
+
+// Statuses returns Status as typed values. Load has already rejected unknown
+// values, so parse errors can't happen on a loaded config.
+func (f TournamentFilter) Statuses() []models.TournamentStatus {
+	statuses, _ := models.ParseEnumList[models.TournamentStatus](f.Status)
+	return statuses
 }
 
 type ClassFilter struct {
-	TenantID          string   `yaml:"tenant_id"`
-	CourseVisibility  string   `yaml:"course_visibility"`
-	ShowOnlyAvailable bool     `yaml:"show_only_available"`
-	Status            string   `yaml:"status"`
-	Type              string   `yaml:"type"`
-	CoachNames        []string `yaml:"coach_names"`
-	PlayerName        string   `yaml:"player_name"`
-	CourseNames       []string `yaml:"course_names"`
-	Blacklist         []string `yaml:"blacklist"`
-}
-
-// TimeWindow defines a time range of interest using HH:MM strings in UTC.
+	TenantID          string            `yaml:"tenant_id"`
+	Tenant            string            `yaml:"tenant"` // alias from Config.Tenants, instead of tenant_id
+	CourseVisibility  models.Visibility `yaml:"course_visibility"`
+	ShowOnlyAvailable bool              `yaml:"show_only_available"`
+	Status            string            `yaml:"status"` // comma-separated, e.g. "PENDING,IN_PROGRESS"
+	Type              string            `yaml:"type"`   // comma-separated, e.g. "COURSE,PUBLIC"
+	CoachNames        []string          `yaml:"coach_names"`
+	CoachNamesMode    MatchMode         `yaml:"coach_names_mode"` // default literal
+	PlayerName        string            `yaml:"player_name"`
+	PlayerNameMode    MatchMode         `yaml:"player_name_mode"` // default word
+	CourseNames       []string          `yaml:"course_names"`
+	CourseNamesMode   MatchMode         `yaml:"course_names_mode"` // default literal
+	Blacklist         []string          `yaml:"blacklist"`
+	BlacklistMode     MatchMode         `yaml:"blacklist_mode"` // default literal
+	// GroupByCourse reports matching sessions once per course ("free seat in
+	// 6 of 8 sessions") instead of once per session.
+	GroupByCourse bool `yaml:"group_by_course"`
+	Proximity     `yaml:",inline"`
+	Identity      `yaml:",inline"`
+
+	// When the class happens, in the club's time zone.
+	Weekdays    []string      `yaml:"weekdays"`      // e.g. ["Tue", "Thursday"]; empty means any day
+	TimeWindows []TimeWindow  `yaml:"time_windows"`  // start time within one of these; empty means any time
+	From        string        `yaml:"from"`          // "2006-01-02", "today" or "+3d"/"+2w" from today
+	Until       string        `yaml:"until"`         // same forms, inclusive
+	MinLeadTime time.Duration `yaml:"min_lead_time"` // e.g. "2h": skip classes starting sooner
+
+	// Where is an optional expression over expr.Classes (see
+	// TournamentFilter.Where).
+	Where     string                      `yaml:"where"`
+	WhereExpr *expr.Program[models.Class] `yaml:"-"`
+}
+
+// IncludesWeekday reports whether d passes Weekdays. Load has already
+// rejected unknown day names.
+func (f ClassFilter) IncludesWeekday(d time.Weekday) bool {
+	if len(f.Weekdays) == 0 {
+		return true
+	}
+	for _, name := range f.Weekdays {
+		if day, err := parseWeekday(name); err == nil && day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// DateRange resolves From and Until against today, a time in the club's
+// zone, to local dates ("2006-01-02"). An empty result means unbounded.
+func (f ClassFilter) DateRange(today time.Time) (from, until string) {
+	from, _ = resolveDate(f.From, today)
+	until, _ = resolveDate(f.Until, today)
+	return from, until
+}
+
+// Statuses returns Status as typed values (see TournamentFilter.Statuses).
+func (f ClassFilter) Statuses() []models.ClassStatus {
+	statuses, _ := models.ParseEnumList[models.ClassStatus](f.Status)
+	return statuses
+}
+
+// Types returns Type as typed values (see TournamentFilter.Statuses).
+func (f ClassFilter) Types() []models.ClassType {
+	types, _ := models.ParseEnumList[models.ClassType](f.Type)
+	return types
+}
+
+// MatchMode is how a filter's name list (blacklist, coach_names, ...) is
+// compared with names. Every mode ignores case and diacritics, so "inigo"
+// matches "Iñigo".
+type MatchMode string
+
+const (
+	MatchLiteral MatchMode = "literal" // substring: "men" matches "women"
+	MatchWord    MatchMode = "word"    // whole words: "men" matches "Men's Cup" only
+	MatchRegex   MatchMode = "regex"   // Go regular expression, e.g. "^open\b"
+	MatchFuzzy   MatchMode = "fuzzy"   // whole words, allowing a typo per five letters
+)
+
+var matchModes = []MatchMode{MatchLiteral, MatchWord, MatchRegex, MatchFuzzy}
+
+// Valid reports whether m is a known mode.
+func (m MatchMode) Valid() bool { return slices.Contains(matchModes, m) }
+
+// Values returns every known mode.
+func (m MatchMode) Values() []MatchMode { return matchModes }
+
+// Or returns m, or def if m is unset.
+func (m MatchMode) Or(def MatchMode) MatchMode {
+	if m == "" {
+		return def
+	}
+	return m
+}
+
+// TimeWindow defines a time range of interest using HH:MM strings in the
+// club's local time (for courts, see CourtTimezone).
 type TimeWindow struct {
 	Start string `yaml:"start"` // e.g. "17:00"
 	End   string `yaml:"end"`   // e.g. "20:00"
@@ -43,59 +217,841 @@ type TimeWindow struct {
 
 // CourtFilter holds configuration for querying court availability.
 type CourtFilter struct {
-	TenantID        string       `yaml:"tenant_id"`
-	SportID         string       `yaml:"sport_id"`
-	TimeWindows     []TimeWindow `yaml:"time_windows"`
-	IgnoredCourtIDs []string     `yaml:"ignored_court_ids"`
-	IgnoredDays     []string     `yaml:"ignored_days"`
+	TenantID        string         `yaml:"tenant_id"`
+	Tenant          string         `yaml:"tenant"` // alias from Config.Tenants, instead of tenant_id
+	SportID         models.SportID `yaml:"sport_id"`
+	TimeWindows     []TimeWindow   `yaml:"time_windows"`
+	IgnoredCourtIDs []string       `yaml:"ignored_court_ids"`
+	IgnoredDays     []string       `yaml:"ignored_days"`
+	Timezone        string         `yaml:"timezone"` // overrides Config.Timezone
+
+	Durations     []int         `yaml:"durations"`      // slot lengths in minutes; default 90
+	MaxPrice      string        `yaml:"max_price"`      // e.g. "40 EUR"; no currency compares amounts only
+	LookaheadDays int           `yaml:"lookahead_days"` // days after today to watch; default 14
+	MinLeadTime   time.Duration `yaml:"min_lead_time"`  // e.g. "30m": skip slots starting sooner
+	Dates         []string      `yaml:"dates"`          // only these local dates, "2006-01-02"
+	ExcludeDates  []string      `yaml:"exclude_dates"`  // never these local dates, e.g. holidays
+
+	// CourtsNeeded and ConsecutiveMinutes turn the filter into a group
+	// search: N courts at the same time, each free for at least that long
+	// (by chaining back-to-back slots if needed).
+	CourtsNeeded       int `yaml:"courts_needed"`
+	ConsecutiveMinutes int `yaml:"consecutive_minutes"`
+}
+
+// IsGroupSearch reports whether the filter looks for combinations of
+// slots rather than individual ones.
+func (f CourtFilter) IsGroupSearch() bool {
+	return f.CourtsNeeded > 1 || f.ConsecutiveMinutes > 0
+}
+
+// DefaultCourtDurations and DefaultLookaheadDays apply when a court filter
+// leaves durations or lookahead_days unset.
+var DefaultCourtDurations = []int{90}
+
+const DefaultLookaheadDays = 14
+
+// SlotDurations returns Durations, or DefaultCourtDurations if unset.
+func (f CourtFilter) SlotDurations() []int {
+	if len(f.Durations) == 0 {
+		return DefaultCourtDurations
+	}
+	return f.Durations
+}
+
+// Lookahead returns LookaheadDays, or DefaultLookaheadDays if unset.
+func (f CourtFilter) Lookahead() int {
+	if f.LookaheadDays <= 0 {
+		return DefaultLookaheadDays
+	}
+	return f.LookaheadDays
+}
+
+// MaxPriceMoney returns MaxPrice parsed, and false if there's no limit.
+// Load has already rejected unparseable prices.
+func (f CourtFilter) MaxPriceMoney() (models.Money, bool) {
+	return maxPrice(f.MaxPrice)
+}
+
+func maxPrice(s string) (models.Money, bool) {
+	if s == "" {
+		return models.Money{}, false
+	}
+	m, err := models.ParseMoney(s)
+	return m, err == nil
+}
+
+// IncludesDate reports whether the local date ("2006-01-02") passes Dates
+// and ExcludeDates.
+func (f CourtFilter) IncludesDate(date string) bool {
+	if len(f.Dates) > 0 && !slices.Contains(f.Dates, date) {
+		return false
+	}
+	return !slices.Contains(f.ExcludeDates, date)
+}
+
+// MatchFilter describes open matches worth joining.
+type MatchFilter struct {
+	TenantIDs        []string          `yaml:"tenant_ids"`
+	Tenants          []string          `yaml:"tenants"` // aliases from Config.Tenants, added to tenant_ids
+	SportID          models.SportID    `yaml:"sport_id"`
+	Visibility       models.Visibility `yaml:"visibility"`
+	Level            float64           `yaml:"level"`  // your level; the match's range must include it
+	Gender           models.Gender     `yaml:"gender"` // e.g. "MIXED"; empty means any
+	MinFreePositions int               `yaml:"min_free_positions"`
+	TimeWindows      []TimeWindow      `yaml:"time_windows"`     // local to each club; empty means any time
+	PlayerName       string            `yaml:"player_name"`      // skip matches this player already joined
+	PlayerNameMode   MatchMode         `yaml:"player_name_mode"` // default word
+	Proximity        `yaml:",inline"`
+	Identity         `yaml:",inline"`
+
+	// Where is an optional expression over expr.Matches (see
+	// TournamentFilter.Where).
+	Where     string                      `yaml:"where"`
+	WhereExpr *expr.Program[models.Match] `yaml:"-"`
+}
+
+// LessonFilter describes lessons worth signing up for.
+type LessonFilter struct {
+	TenantID           string            `yaml:"tenant_id"`
+	Tenant             string            `yaml:"tenant"` // alias from Config.Tenants, instead of tenant_id
+	Visibility         models.Visibility `yaml:"visibility"`
+	Status             string            `yaml:"status"`            // comma-separated, e.g. "REGISTRATION_OPEN"
+	LevelDescription   string            `yaml:"level_description"` // substring, e.g. "intermediate"
+	Gender             models.Gender     `yaml:"gender"`
+	Tags               []string          `yaml:"tags"`      // lesson must carry at least one
+	MaxPrice           string            `yaml:"max_price"` // e.g. "20 EUR"; no currency compares amounts only
+	MinAvailablePlaces int               `yaml:"min_available_places"`
+	Blacklist          []string          `yaml:"blacklist"`
+	BlacklistMode      MatchMode         `yaml:"blacklist_mode"` // default literal
+	PlayerName         string            `yaml:"player_name"`
+	PlayerNameMode     MatchMode         `yaml:"player_name_mode"` // default word
+	Proximity          `yaml:",inline"`
+	Identity           `yaml:",inline"`
+	// ClosingWithin, e.g. "24h", sends one more alert once registration
+	// closes within that long. Zero disables it.
+	ClosingWithin time.Duration `yaml:"closing_within"`
+
+	// Where is an optional expression over expr.Lessons (see
+	// TournamentFilter.Where).
+	Where     string                       `yaml:"where"`
+	WhereExpr *expr.Program[models.Lesson] `yaml:"-"`
+}
+
+// Statuses returns Status as typed values (see TournamentFilter.Statuses).
+func (f LessonFilter) Statuses() []models.TournamentStatus {
+	statuses, _ := models.ParseEnumList[models.TournamentStatus](f.Status)
+	return statuses
+}
+
+// MaxPriceMoney returns MaxPrice parsed, and false if there's no limit.
+func (f LessonFilter) MaxPriceMoney() (models.Money, bool) {
+	return maxPrice(f.MaxPrice)
+}
+
+// CoachFilter selects which coaches' schedules to print for a tenant.
+type CoachFilter struct {
+	TenantID       string    `yaml:"tenant_id"`
+	Tenant         string    `yaml:"tenant"`           // alias from Config.Tenants, instead of tenant_id
+	CoachNames     []string  `yaml:"coach_names"`      // empty means every coach
+	CoachNamesMode MatchMode `yaml:"coach_names_mode"` // default literal
 }
 
+// Load reads the config at path, along with the files it includes, and
+// applies its defaults (see loader for how files merge).
 func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
+	return LoadProfile(path, "")
+}
+
+// LoadProfile is Load with the named entry of profiles: merged over the
+// config before defaults are applied. An empty name selects no profile.
+// Errors point at the file and line they're about.
+func LoadProfile(path, profile string) (*Config, error) {
+	l := newLoader(path)
+	root, err := l.load(path)
 	if err != nil {
-		return nil, fmt.Errorf("reading config file: %w", err)
+		return nil, err
+	}
+	if err := l.applyProfile(root, profile); err != nil {
+		return nil, err
+	}
+	if err := l.applyDefaults(root); err != nil {
+		return nil, err
 	}
 
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	if err := l.decode(root, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config file: %w", err)
 	}
 
 	if err := cfg.validate(); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
+		return nil, fmt.Errorf("%s: invalid config: %w", l.position(locate(root, err)), err)
+	}
+	if err := cfg.resolve(); err != nil {
+		return nil, fmt.Errorf("%s: invalid config: %w", l.position(locate(root, err)), err)
+	}
+	cfg.resolveLocations()
+	if cfg.Me.UserID != "" {
+		cfg.SetUserID(cfg.Me.UserID)
 	}
 
 	return &cfg, nil
 }
 
+// pathError is a validation error about the option at key, or about item
+// index of it when it's a list. Nested pathErrors spell out the path from
+// the top of the config, which Load follows to report the option's file
+// and line.
+type pathError struct {
+	key   string
+	index int // -1 when the error isn't about one list item
+	err   error
+}
+
+func (e *pathError) Error() string {
+	if e.index < 0 {
+		return fmt.Sprintf("%s: %v", e.key, e.err)
+	}
+	return fmt.Sprintf("%s[%d]: %v", e.key, e.index, e.err)
+}
+
+func (e *pathError) Unwrap() error { return e.err }
+
+func fieldErr(key string, err error) error {
+	return &pathError{key: key, index: -1, err: err}
+}
+
+func itemErr(key string, index int, err error) error {
+	return &pathError{key: key, index: index, err: err}
+}
+
 func (c *Config) validate() error {
-	if len(c.Tournaments) == 0 && len(c.Classes) == 0 && len(c.Courts) == 0 {
-		return fmt.Errorf("at least one tournament, class, or court filter is required")
+	if len(c.Tournaments) == 0 && len(c.Classes) == 0 && len(c.Courts) == 0 && len(c.Coaches) == 0 && len(c.Matches) == 0 && len(c.Lessons) == 0 {
+		return fmt.Errorf("at least one tournament, class, court, coach, match, or lesson filter is required")
+	}
+
+	if err := validateTimezone(c.Timezone); err != nil {
+		return err
+	}
+
+	if c.Me.UserID != "" && c.Me.FromToken {
+		return fieldErr("me", errors.New("set user_id or from_token, not both"))
+	}
+	for i, id := range c.Friends {
+		if strings.TrimSpace(id) == "" {
+			return itemErr("friends", i, errors.New("user ID must not be empty"))
+		}
+	}
+
+	if err := c.validateTenants(); err != nil {
+		return err
+	}
+
+	if c.RankBy != "" {
+		if _, ok := c.Locations[c.RankBy]; !ok {
+			return fieldErr("rank_by", fmt.Errorf("unknown location %q", c.RankBy))
+		}
 	}
 
 	for i, t := range c.Tournaments {
+		id, err := c.resolveTenant(t.Tenant, t.TenantID)
+		if err != nil {
+			return itemErr("tournaments", i, err)
+		}
+		t.TenantID = id
 		if t.TenantID == "" {
-			return fmt.Errorf("tournaments[%d]: tenant_id is required", i)
+			return itemErr("tournaments", i, errors.New("tenant_id is required"))
+		}
+		if err := c.validateProximity(t.Proximity); err != nil {
+			return itemErr("tournaments", i, err)
+		}
+		if err := validateEnum(t.Visibility); err != nil {
+			return itemErr("tournaments", i, fieldErr("visibility", err))
+		}
+		if err := validateEnum(t.RegistrationStatus); err != nil {
+			return itemErr("tournaments", i, fieldErr("registration_status", err))
+		}
+		if _, err := models.ParseEnumList[models.TournamentStatus](t.Status); err != nil {
+			return itemErr("tournaments", i, fieldErr("status", err))
+		}
+		if err := validateNames("blacklist", t.BlacklistMode, t.Blacklist...); err != nil {
+			return itemErr("tournaments", i, err)
+		}
+		if err := c.validateIdentity(t.Identity); err != nil {
+			return itemErr("tournaments", i, err)
+		}
+		if err := validateNames("player_name", t.PlayerNameMode, t.PlayerName); err != nil {
+			return itemErr("tournaments", i, err)
+		}
+		if _, err := compileWhere(t.Where, expr.Tournaments); err != nil {
+			return itemErr("tournaments", i, err)
 		}
 	}
 
 	for i, cl := range c.Classes {
+		id, err := c.resolveTenant(cl.Tenant, cl.TenantID)
+		if err != nil {
+			return itemErr("classes", i, err)
+		}
+		cl.TenantID = id
 		if cl.TenantID == "" {
-			return fmt.Errorf("classes[%d]: tenant_id is required", i)
+			return itemErr("classes", i, errors.New("tenant_id is required"))
+		}
+		if err := c.validateProximity(cl.Proximity); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if err := validateEnum(cl.CourseVisibility); err != nil {
+			return itemErr("classes", i, fieldErr("course_visibility", err))
+		}
+		if _, err := models.ParseEnumList[models.ClassStatus](cl.Status); err != nil {
+			return itemErr("classes", i, fieldErr("status", err))
+		}
+		if _, err := models.ParseEnumList[models.ClassType](cl.Type); err != nil {
+			return itemErr("classes", i, fieldErr("type", err))
+		}
+		if err := validateNames("coach_names", cl.CoachNamesMode, cl.CoachNames...); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if err := validateNames("player_name", cl.PlayerNameMode, cl.PlayerName); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if err := validateNames("course_names", cl.CourseNamesMode, cl.CourseNames...); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if err := validateNames("blacklist", cl.BlacklistMode, cl.Blacklist...); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if err := validateClassSchedule(cl); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if err := c.validateIdentity(cl.Identity); err != nil {
+			return itemErr("classes", i, err)
+		}
+		if _, err := compileWhere(cl.Where, expr.Classes); err != nil {
+			return itemErr("classes", i, err)
 		}
 	}
 
 	for i, ct := range c.Courts {
+		id, err := c.resolveTenant(ct.Tenant, ct.TenantID)
+		if err != nil {
+			return itemErr("courts", i, err)
+		}
+		ct.TenantID = id
 		if ct.TenantID == "" {
-			return fmt.Errorf("courts[%d]: tenant_id is required", i)
+			return itemErr("courts", i, errors.New("tenant_id is required"))
 		}
 		if ct.SportID == "" {
-			return fmt.Errorf("courts[%d]: sport_id is required", i)
+			return itemErr("courts", i, errors.New("sport_id is required"))
+		}
+		if err := validateEnum(ct.SportID); err != nil {
+			return itemErr("courts", i, fieldErr("sport_id", err))
 		}
 		if len(ct.TimeWindows) == 0 {
-			return fmt.Errorf("courts[%d]: at least one time_window is required", i)
+			return itemErr("courts", i, errors.New("at least one time_window is required"))
+		}
+		if err := validateTimeWindows(ct.TimeWindows); err != nil {
+			return itemErr("courts", i, err)
+		}
+		if err := validateTimezone(ct.Timezone); err != nil {
+			return itemErr("courts", i, err)
+		}
+		if err := validateCourtOptions(ct); err != nil {
+			return itemErr("courts", i, err)
+		}
+	}
+
+	for i, m := range c.Matches {
+		for j, alias := range m.Tenants {
+			id, err := c.resolveTenant(alias, "")
+			if err != nil {
+				return itemErr("matches", i, itemErr("tenants", j, err))
+			}
+			m.TenantIDs = append(slices.Clip(m.TenantIDs), id)
+		}
+		if len(m.TenantIDs) == 0 {
+			return itemErr("matches", i, errors.New("at least one tenant_id in tenant_ids is required"))
+		}
+		if err := validateEnum(m.SportID); err != nil {
+			return itemErr("matches", i, fieldErr("sport_id", err))
+		}
+		if err := validateEnum(m.Visibility); err != nil {
+			return itemErr("matches", i, fieldErr("visibility", err))
+		}
+		if err := validateEnum(m.Gender); err != nil {
+			return itemErr("matches", i, fieldErr("gender", err))
+		}
+		if m.Level < 0 {
+			return itemErr("matches", i, errors.New("level must not be negative"))
+		}
+		if m.MinFreePositions < 0 {
+			return itemErr("matches", i, errors.New("min_free_positions must not be negative"))
+		}
+		if err := validateTimeWindows(m.TimeWindows); err != nil {
+			return itemErr("matches", i, err)
+		}
+		if err := validateNames("player_name", m.PlayerNameMode, m.PlayerName); err != nil {
+			return itemErr("matches", i, err)
+		}
+		if err := c.validateProximity(m.Proximity); err != nil {
+			return itemErr("matches", i, err)
+		}
+		if err := c.validateIdentity(m.Identity); err != nil {
+			return itemErr("matches", i, err)
+		}
+		if _, err := compileWhere(m.Where, expr.Matches); err != nil {
+			return itemErr("matches", i, err)
+		}
+	}
+
+	for i, l := range c.Lessons {
+		id, err := c.resolveTenant(l.Tenant, l.TenantID)
+		if err != nil {
+			return itemErr("lessons", i, err)
+		}
+		l.TenantID = id
+		if l.TenantID == "" {
+			return itemErr("lessons", i, errors.New("tenant_id is required"))
+		}
+		if err := validateEnum(l.Visibility); err != nil {
+			return itemErr("lessons", i, fieldErr("visibility", err))
+		}
+		if _, err := models.ParseEnumList[models.TournamentStatus](l.Status); err != nil {
+			return itemErr("lessons", i, fieldErr("status", err))
+		}
+		if err := validateEnum(l.Gender); err != nil {
+			return itemErr("lessons", i, fieldErr("gender", err))
 		}
+		if l.MaxPrice != "" {
+			if _, err := models.ParseMoney(l.MaxPrice); err != nil {
+				return itemErr("lessons", i, fieldErr("max_price", err))
+			}
+		}
+		if l.MinAvailablePlaces < 0 {
+			return itemErr("lessons", i, errors.New("min_available_places must not be negative"))
+		}
+		if l.ClosingWithin < 0 {
+			return itemErr("lessons", i, errors.New("closing_within must not be negative"))
+		}
+		if err := validateNames("blacklist", l.BlacklistMode, l.Blacklist...); err != nil {
+			return itemErr("lessons", i, err)
+		}
+		if err := validateNames("player_name", l.PlayerNameMode, l.PlayerName); err != nil {
+			return itemErr("lessons", i, err)
+		}
+		if err := c.validateProximity(l.Proximity); err != nil {
+			return itemErr("lessons", i, err)
+		}
+		if err := c.validateIdentity(l.Identity); err != nil {
+			return itemErr("lessons", i, err)
+		}
+		if _, err := compileWhere(l.Where, expr.Lessons); err != nil {
+			return itemErr("lessons", i, err)
+		}
+	}
+
+	for i, co := range c.Coaches {
+		id, err := c.resolveTenant(co.Tenant, co.TenantID)
+		if err != nil {
+			return itemErr("coaches", i, err)
+		}
+		co.TenantID = id
+		if co.TenantID == "" {
+			return itemErr("coaches", i, errors.New("tenant_id is required"))
+		}
+		if err := validateNames("coach_names", co.CoachNamesMode, co.CoachNames...); err != nil {
+			return itemErr("coaches", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateProximity(p Proximity) error {
+	if p.MaxDistanceKm < 0 {
+		return fmt.Errorf("max_distance_km must not be negative")
+	}
+	if p.Near == "" {
+		if p.MaxDistanceKm > 0 {
+			return fmt.Errorf("max_distance_km requires near")
+		}
+		return nil
+	}
+	if _, ok := c.Locations[p.Near]; !ok {
+		return fieldErr("near", fmt.Errorf("unknown location %q", p.Near))
 	}
+	return nil
+}
 
+func (c *Config) validateIdentity(id Identity) error {
+	if id.SkipJoined && c.Me.UserID == "" && !c.Me.FromToken {
+		return fmt.Errorf("skip_joined requires me.user_id or me.from_token")
+	}
 	return nil
 }
+
+// SetUserID sets Me.UserID and hands it to every filter's Identity. Load
+// calls it for a configured user_id; with from_token, the caller does once
+// it has an access token.
+func (c *Config) SetUserID(id string) {
+	c.Me.UserID = id
+	for i := range c.Tournaments {
+		c.Tournaments[i].UserID = id
+	}
+	for i := range c.Classes {
+		c.Classes[i].UserID = id
+	}
+	for i := range c.Matches {
+		c.Matches[i].UserID = id
+	}
+	for i := range c.Lessons {
+		c.Lessons[i].UserID = id
+	}
+}
+
+// resolve fills in what validate only checks: each filter's tenant ID from
+// its tenant alias, and its WhereExpr compiled from Where.
+func (c *Config) resolve() error {
+	for i := range c.Tournaments {
+		t := &c.Tournaments[i]
+		id, err := c.resolveTenant(t.Tenant, t.TenantID)
+		if err != nil {
+			return itemErr("tournaments", i, err)
+		}
+		t.TenantID = id
+		if t.WhereExpr, err = compileWhere(t.Where, expr.Tournaments); err != nil {
+			return itemErr("tournaments", i, err)
+		}
+	}
+	for i := range c.Classes {
+		cl := &c.Classes[i]
+		id, err := c.resolveTenant(cl.Tenant, cl.TenantID)
+		if err != nil {
+			return itemErr("classes", i, err)
+		}
+		cl.TenantID = id
+		if cl.WhereExpr, err = compileWhere(cl.Where, expr.Classes); err != nil {
+			return itemErr("classes", i, err)
+		}
+	}
+	for i := range c.Courts {
+		ct := &c.Courts[i]
+		id, err := c.resolveTenant(ct.Tenant, ct.TenantID)
+		if err != nil {
+			return itemErr("courts", i, err)
+		}
+		ct.TenantID = id
+	}
+	for i := range c.Matches {
+		m := &c.Matches[i]
+		for j, alias := range m.Tenants {
+			id, err := c.resolveTenant(alias, "")
+			if err != nil {
+				return itemErr("matches", i, itemErr("tenants", j, err))
+			}
+			m.TenantIDs = append(slices.Clip(m.TenantIDs), id)
+		}
+		var err error
+		if m.WhereExpr, err = compileWhere(m.Where, expr.Matches); err != nil {
+			return itemErr("matches", i, err)
+		}
+	}
+	for i := range c.Lessons {
+		l := &c.Lessons[i]
+		id, err := c.resolveTenant(l.Tenant, l.TenantID)
+		if err != nil {
+			return itemErr("lessons", i, err)
+		}
+		l.TenantID = id
+		if l.WhereExpr, err = compileWhere(l.Where, expr.Lessons); err != nil {
+			return itemErr("lessons", i, err)
+		}
+	}
+	for i := range c.Coaches {
+		co := &c.Coaches[i]
+		id, err := c.resolveTenant(co.Tenant, co.TenantID)
+		if err != nil {
+			return itemErr("coaches", i, err)
+		}
+		co.TenantID = id
+	}
+	return nil
+}
+
+// resolveLocations points each filter's Proximity.Origin at its named
+// location. validate has already checked the names exist.
+func (c *Config) resolveLocations() {
+	origin := func(name string) *models.Coordinate {
+		if name == "" {
+			return nil
+		}
+		loc := c.Locations[name]
+		return &loc
+	}
+	for i := range c.Tournaments {
+		c.Tournaments[i].Origin = origin(c.Tournaments[i].Near)
+	}
+	for i := range c.Classes {
+		c.Classes[i].Origin = origin(c.Classes[i].Near)
+	}
+	for i := range c.Matches {
+		c.Matches[i].Origin = origin(c.Matches[i].Near)
+	}
+	for i := range c.Lessons {
+		c.Lessons[i].Origin = origin(c.Lessons[i].Near)
+	}
+}
+
+// CourtTimezone returns the configured zone for cf: its own timezone, else
+// its club's in Tenants, else the config-wide one. Empty means the caller
+// should use the club's zone.
+func (c *Config) CourtTimezone(cf CourtFilter) string {
+	if cf.Timezone != "" {
+		return cf.Timezone
+	}
+	if _, t, ok := c.tenantByID(cf.TenantID); ok && t.Timezone != "" {
+		return t.Timezone
+	}
+	return c.Timezone
+}
+
+// TenantName returns a club's display name from Tenants, else its alias,
+// else the ID itself.
+func (c *Config) TenantName(id string) string {
+	alias, t, ok := c.tenantByID(id)
+	switch {
+	case !ok:
+		return id
+	case t.Name != "":
+		return t.Name
+	}
+	return alias
+}
+
+// tenantByID finds the Tenants entry for id, taking the first alias in
+// sorted order if several share it.
+func (c *Config) tenantByID(id string) (string, Tenant, bool) {
+	for _, alias := range slices.Sorted(maps.Keys(c.Tenants)) {
+		if t := c.Tenants[alias]; t.ID == id {
+			return alias, t, true
+		}
+	}
+	return "", Tenant{}, false
+}
+
+func (c *Config) validateTenants() error {
+	for _, alias := range slices.Sorted(maps.Keys(c.Tenants)) {
+		t := c.Tenants[alias]
+		if t.ID == "" {
+			return fieldErr("tenants", fieldErr(alias, errors.New("id is required")))
+		}
+		if err := validateTimezone(t.Timezone); err != nil {
+			return fieldErr("tenants", fieldErr(alias, err))
+		}
+	}
+	return nil
+}
+
+// resolveTenant returns the tenant ID a filter means: alias looked up in
+// Tenants, else id as given.
+func (c *Config) resolveTenant(alias, id string) (string, error) {
+	if alias == "" {
+		return id, nil
+	}
+	if id != "" {
+		return "", fieldErr("tenant", errors.New("set tenant or tenant_id, not both"))
+	}
+	t, ok := c.Tenants[alias]
+	switch {
+	case !ok && len(c.Tenants) == 0:
+		return "", fieldErr("tenant", fmt.Errorf("unknown alias %q (no tenants configured)", alias))
+	case !ok:
+		return "", fieldErr("tenant", fmt.Errorf("unknown alias %q (tenants: %s)", alias, strings.Join(slices.Sorted(maps.Keys(c.Tenants)), ", ")))
+	}
+	return t.ID, nil
+}
+
+func validateCourtOptions(ct CourtFilter) error {
+	for _, d := range ct.Durations {
+		if d <= 0 {
+			return fieldErr("durations", fmt.Errorf("%d is not a positive number of minutes", d))
+		}
+	}
+	if ct.MaxPrice != "" {
+		if _, err := models.ParseMoney(ct.MaxPrice); err != nil {
+			return fieldErr("max_price", err)
+		}
+	}
+	if ct.LookaheadDays < 0 {
+		return fmt.Errorf("lookahead_days must not be negative")
+	}
+	if ct.MinLeadTime < 0 {
+		return fmt.Errorf("min_lead_time must not be negative")
+	}
+	if ct.CourtsNeeded < 0 {
+		return fmt.Errorf("courts_needed must not be negative")
+	}
+	if ct.ConsecutiveMinutes < 0 {
+		return fmt.Errorf("consecutive_minutes must not be negative")
+	}
+	for _, d := range ct.Dates {
+		if _, err := models.ParseDate(d); err != nil {
+			return fieldErr("dates", err)
+		}
+	}
+	for _, d := range ct.ExcludeDates {
+		if _, err := models.ParseDate(d); err != nil {
+			return fieldErr("exclude_dates", err)
+		}
+	}
+	return nil
+}
+
+func validateTimeWindows(windows []TimeWindow) error {
+	for i, w := range windows {
+		start, err := models.ParseTimeOfDay(w.Start)
+		if err != nil {
+			return itemErr("time_windows", i, fieldErr("start", err))
+		}
+		end, err := models.ParseTimeOfDay(w.End)
+		if err != nil {
+			return itemErr("time_windows", i, fieldErr("end", err))
+		}
+		if start.Minutes() >= end.Minutes() {
+			return itemErr("time_windows", i, fmt.Errorf("start %s is not before end %s", w.Start, w.End))
+		}
+	}
+	return nil
+}
+
+// validateNames checks a name list's mode and, for regex lists, that every
+// pattern compiles.
+func validateNames(field string, mode MatchMode, names ...string) error {
+	if err := validateEnum(mode); err != nil {
+		return fieldErr(field+"_mode", err)
+	}
+	if mode != MatchRegex {
+		return nil
+	}
+	for _, name := range names {
+		if _, err := regexp.Compile(name); err != nil {
+			return fieldErr(field, err)
+		}
+	}
+	return nil
+}
+
+// compileWhere compiles a filter's where: expression against schema. An
+// empty expression yields a nil Program, which matches everything.
+func compileWhere[T any](src string, schema expr.Schema[T]) (*expr.Program[T], error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, nil
+	}
+	p, err := expr.Compile(src, schema)
+	if err != nil {
+		return nil, fieldErr("where", err)
+	}
+	return p, nil
+}
+
+func validateClassSchedule(cl ClassFilter) error {
+	for _, d := range cl.Weekdays {
+		if _, err := parseWeekday(d); err != nil {
+			return fieldErr("weekdays", err)
+		}
+	}
+	if err := validateTimeWindows(cl.TimeWindows); err != nil {
+		return err
+	}
+	from, err := resolveDate(cl.From, time.Now())
+	if err != nil {
+		return fieldErr("from", err)
+	}
+	until, err := resolveDate(cl.Until, time.Now())
+	if err != nil {
+		return fieldErr("until", err)
+	}
+	// Relative bounds move together, so only compare fixed dates.
+	if from != "" && until != "" && from > until && !isRelativeDate(cl.From) && !isRelativeDate(cl.Until) {
+		return fmt.Errorf("from %s is after until %s", cl.From, cl.Until)
+	}
+	if cl.MinLeadTime < 0 {
+		return fmt.Errorf("min_lead_time must not be negative")
+	}
+	return nil
+}
+
+// parseWeekday accepts English day names, full or abbreviated to three
+// letters, in any case.
+func parseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q (expected e.g. Mon or Monday)", s)
+}
+
+// resolveDate turns a date option into a local date relative to today:
+// "2006-01-02" as is, "today", or "+Nd"/"+Nw" days or weeks ahead. Empty
+// stays empty.
+func resolveDate(s string, today time.Time) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return "", nil
+	case strings.EqualFold(s, "today"):
+		return today.Format(models.DateFormat), nil
+	case isRelativeDate(s):
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid relative date %q (expected e.g. +3d or +2w)", s)
+		}
+		if s[len(s)-1] == 'w' {
+			n *= 7
+		}
+		y, m, d := today.Date()
+		return time.Date(y, m, d+n, 0, 0, 0, 0, today.Location()).Format(models.DateFormat), nil
+	}
+	date, err := models.ParseDate(s)
+	if err != nil {
+		return "", err
+	}
+	return date.String(), nil
+}
+
+func isRelativeDate(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "+") && (strings.HasSuffix(s, "d") || strings.HasSuffix(s, "w"))
+}
+
+func validateTimezone(tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fieldErr("timezone", err)
+	}
+	return nil
+}
+
+// RankOrigin returns the RankBy location, if one is configured.
+func (c *Config) RankOrigin() (models.Coordinate, bool) {
+	if c.RankBy == "" {
+		return models.Coordinate{}, false
+	}
+	loc, ok := c.Locations[c.RankBy]
+	return loc, ok
+}
+
+// validateEnum checks an optional single-valued enum field: empty is allowed,
+// anything else must be a known value.
+func validateEnum[T models.Enum[T]](v T) error {
+	if v == "" {
+		return nil
+	}
+	_, err := models.ParseEnum[T](string(v))
+	return err
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -78,6 +81,100 @@ func TestLoad_NoTournaments(t *testing.T) {
 	}
 }
 
+func TestLoad_Coaches(t *testing.T) {
+	content := []byte(`coaches:
+  - tenant_id: "tenant-1"
+    coach_names:
+      - "Ana"
+`)
+	path := writeTempFile(t, content)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Coaches) != 1 {
+		t.Fatalf("expected 1 coach filter, got %d", len(cfg.Coaches))
+	}
+	if cfg.Coaches[0].CoachNames[0] != "Ana" {
+		t.Errorf("expected coach_names[0] 'Ana', got %q", cfg.Coaches[0].CoachNames[0])
+	}
+
+	_, err = Load(writeTempFile(t, []byte(`coaches:
+  - coach_names: ["Ana"]
+`)))
+	if err == nil {
+		t.Fatal("expected validation error for coach filter without tenant_id, got nil")
+	}
+}
+
+func TestLoad_UnknownEnumValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "tournament visibility",
+			content: `tournaments:
+  - tenant_id: "tenant-1"
+    visibility: "PUBLC"
+`,
+			want: `tournaments[0]: visibility: unknown value "PUBLC"`,
+		},
+		{
+			name: "class status list",
+			content: `classes:
+  - tenant_id: "tenant-1"
+    status: "PENDING,INPROGRESS"
+`,
+			want: `classes[0]: status: unknown value "INPROGRESS"`,
+		},
+		{
+			name: "court sport",
+			content: `courts:
+  - tenant_id: "tenant-1"
+    sport_id: "padel"
+    time_windows:
+      - start: "17:00"
+        end: "20:00"
+`,
+			want: `did you mean "PADEL"?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeTempFile(t, []byte(tt.content)))
+			if err == nil {
+				t.Fatal("expected validation error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %q", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestLoad_RepoConfigs makes sure the configs the CI workflows run with
+// still pass validation.
+func TestLoad_RepoConfigs(t *testing.T) {
+	paths, err := filepath.Glob("../../configs/*.yaml")
+	if err != nil {
+		t.Fatalf("globbing configs: %v", err)
+	}
+	paths = append(paths, "../../config.example.yaml")
+
+	for _, path := range paths {
+		if _, err := Load(path); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+	if _, err := LoadProfile("../../config.example.yaml", "weekend"); err != nil {
+		t.Errorf("config.example.yaml, profile weekend: %v", err)
+	}
+}
+
 func TestLoad_FileNotFound(t *testing.T) {
 	_, err := Load("/nonexistent/config.yaml")
 	if err == nil {
@@ -104,3 +201,639 @@ func writeTempFile(t *testing.T, content []byte) string {
 	}
 	return path
 }
+
+func TestLoad_Locations(t *testing.T) {
+	content := []byte(`locations:
+  home: {lat: 52.52, lon: 13.405}
+  work: {lat: 52.39, lon: 13.06}
+rank_by: home
+classes:
+  - tenant_id: "tenant-1"
+    near: work
+    max_distance_km: 10
+  - tenant_id: "tenant-2"
+matches:
+  - tenant_ids: ["tenant-1"]
+    near: home
+    max_distance_km: 5
+lessons:
+  - tenant_id: "tenant-1"
+    near: work
+`)
+	cfg, err := Load(writeTempFile(t, content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if origin, ok := cfg.RankOrigin(); !ok || origin.Lat != 52.52 {
+		t.Errorf("expected rank origin at home, got %+v, %v", origin, ok)
+	}
+	if o := cfg.Classes[0].Origin; o == nil || o.Lon != 13.06 {
+		t.Errorf("expected classes[0] origin at work, got %+v", o)
+	}
+	if cfg.Classes[1].Origin != nil {
+		t.Errorf("expected no origin for classes[1], got %+v", cfg.Classes[1].Origin)
+	}
+	if o := cfg.Matches[0].Origin; o == nil || o.Lat != 52.52 {
+		t.Errorf("expected matches[0] origin at home, got %+v", o)
+	}
+	if o := cfg.Lessons[0].Origin; o == nil || o.Lon != 13.06 {
+		t.Errorf("expected lessons[0] origin at work, got %+v", o)
+	}
+
+	invalid := map[string]string{
+		"unknown near": `locations: {home: {lat: 1, lon: 1}}
+classes:
+  - tenant_id: "t"
+    near: gym
+`,
+		"distance without near": `classes:
+  - tenant_id: "t"
+    max_distance_km: 5
+`,
+		"unknown near on a match": `locations: {home: {lat: 1, lon: 1}}
+matches:
+  - tenant_ids: ["t"]
+    near: gym
+`,
+		"distance without near on a lesson": `lessons:
+  - tenant_id: "t"
+    max_distance_km: 5
+`,
+		"unknown rank_by": `rank_by: home
+classes:
+  - tenant_id: "t"
+`,
+	}
+	for name, content := range invalid {
+		if _, err := Load(writeTempFile(t, []byte(content))); err == nil {
+			t.Errorf("%s: expected validation error, got nil", name)
+		}
+	}
+
+	windows := map[string]string{
+		`[{start: "25:00", end: "26:00"}]`: "courts[0]: time_windows[0]: start:",
+		`[{start: "17:00", end: "7pm"}]`:   "courts[0]: time_windows[0]: end:",
+		`[{start: "20:00", end: "18:00"}]`: "courts[0]: time_windows[0]: start 20:00 is not before end 18:00",
+	}
+	for window, want := range windows {
+		content := `courts:
+  - tenant_id: "tenant-1"
+    sport_id: "PADEL"
+    time_windows: ` + window + "\n"
+		if _, err := Load(writeTempFile(t, []byte(content))); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", window, want, err)
+		}
+	}
+}
+
+func TestLoad_CourtTimezone(t *testing.T) {
+	content := []byte(`timezone: "Europe/Berlin"
+courts:
+  - tenant_id: "tenant-1"
+    sport_id: "PADEL"
+    time_windows: [{start: "17:00", end: "20:00"}]
+  - tenant_id: "tenant-2"
+    sport_id: "PADEL"
+    timezone: "Europe/Madrid"
+    time_windows: [{start: "17:00", end: "20:00"}]
+`)
+	cfg, err := Load(writeTempFile(t, content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.CourtTimezone(cfg.Courts[0]); got != "Europe/Berlin" {
+		t.Errorf("expected config-wide timezone, got %q", got)
+	}
+	if got := cfg.CourtTimezone(cfg.Courts[1]); got != "Europe/Madrid" {
+		t.Errorf("expected filter timezone, got %q", got)
+	}
+
+	_, err = Load(writeTempFile(t, []byte(`courts:
+  - tenant_id: "tenant-1"
+    sport_id: "PADEL"
+    timezone: "Mars/Olympus"
+    time_windows: [{start: "17:00", end: "20:00"}]
+`)))
+	if err == nil {
+		t.Fatal("expected validation error for unknown timezone, got nil")
+	}
+}
+
+func TestLoad_CourtOptions(t *testing.T) {
+	content := []byte(`courts:
+  - tenant_id: "tenant-1"
+    sport_id: "PADEL"
+    time_windows: [{start: "17:00", end: "20:00"}]
+    durations: [60, 120]
+    max_price: "40 EUR"
+    lookahead_days: 3
+    min_lead_time: 30m
+    exclude_dates: ["2026-12-24"]
+`)
+	cfg, err := Load(writeTempFile(t, content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ct := cfg.Courts[0]
+	if ct.MinLeadTime != 30*time.Minute {
+		t.Errorf("expected min_lead_time 30m, got %s", ct.MinLeadTime)
+	}
+	if ct.Lookahead() != 3 || len(ct.SlotDurations()) != 2 {
+		t.Errorf("unexpected lookahead %d or durations %v", ct.Lookahead(), ct.SlotDurations())
+	}
+	if price, ok := ct.MaxPriceMoney(); !ok || price.String() != "40 EUR" {
+		t.Errorf("expected max price 40 EUR, got %v, %v", price, ok)
+	}
+	if ct.IncludesDate("2026-12-24") || !ct.IncludesDate("2026-12-23") {
+		t.Error("expected exclude_dates to be honored")
+	}
+
+	if ct.IsGroupSearch() {
+		t.Error("expected a single-slot search without courts_needed or consecutive_minutes")
+	}
+	if !(CourtFilter{CourtsNeeded: 2}).IsGroupSearch() || !(CourtFilter{ConsecutiveMinutes: 180}).IsGroupSearch() {
+		t.Error("expected courts_needed or consecutive_minutes to make a group search")
+	}
+
+	defaults := CourtFilter{}
+	if defaults.Lookahead() != DefaultLookaheadDays || defaults.SlotDurations()[0] != 90 {
+		t.Errorf("unexpected defaults %d, %v", defaults.Lookahead(), defaults.SlotDurations())
+	}
+
+	invalid := map[string]string{
+		"bad price":    `max_price: "forty"`,
+		"bad duration": `durations: [0]`,
+		"bad date":     `dates: ["24.12.2026"]`,
+		"bad courts":   `courts_needed: -1`,
+	}
+	for name, option := range invalid {
+		content := `courts:
+  - tenant_id: "tenant-1"
+    sport_id: "PADEL"
+    time_windows: [{start: "17:00", end: "20:00"}]
+    ` + option + "\n"
+		if _, err := Load(writeTempFile(t, []byte(content))); err == nil {
+			t.Errorf("%s: expected validation error, got nil", name)
+		}
+	}
+}
+
+func TestLoad_Matches(t *testing.T) {
+	content := []byte(`matches:
+  - tenant_ids: ["tenant-1", "tenant-2"]
+    sport_id: "PADEL"
+    level: 3.2
+    gender: "MIXED"
+    min_free_positions: 1
+    time_windows: [{start: "18:00", end: "21:00"}]
+`)
+	cfg, err := Load(writeTempFile(t, content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := cfg.Matches[0]
+	if len(m.TenantIDs) != 2 || m.Level != 3.2 || m.Gender != "MIXED" || len(m.TimeWindows) != 1 {
+		t.Errorf("unexpected match filter %+v", m)
+	}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"matches:\n  - sport_id: \"PADEL\"\n", "matches[0]: at least one tenant_id"},
+		{"matches:\n  - tenant_ids: [\"t\"]\n    gender: \"ANY\"\n", `matches[0]: gender: unknown value "ANY"`},
+		{"matches:\n  - tenant_ids: [\"t\"]\n    min_free_positions: -1\n", "matches[0]: min_free_positions must not be negative"},
+		{"matches:\n  - tenant_ids: [\"t\"]\n    time_windows: [{start: \"25:00\", end: \"26:00\"}]\n", "matches[0]: time_windows[0]: start:"},
+		{"matches:\n  - tenant_ids: [\"t\"]\n    time_windows: [{start: \"21:00\", end: \"18:00\"}]\n", "matches[0]: time_windows[0]: start 21:00 is not before end 18:00"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeTempFile(t, []byte(tt.content)))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected error containing %q, got %v", tt.want, err)
+		}
+	}
+}
+
+func TestLoad_Lessons(t *testing.T) {
+	content := []byte(`lessons:
+  - tenant_id: "tenant-1"
+    visibility: "PUBLIC"
+    status: "REGISTRATION_OPEN"
+    level_description: "intermediate"
+    tags: ["drills"]
+    max_price: "20 EUR"
+    closing_within: "24h"
+`)
+	cfg, err := Load(writeTempFile(t, content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l := cfg.Lessons[0]
+	if l.ClosingWithin != 24*time.Hour {
+		t.Errorf("expected closing_within 24h, got %s", l.ClosingWithin)
+	}
+	if limit, ok := l.MaxPriceMoney(); !ok || limit.String() != "20 EUR" {
+		t.Errorf("expected max price 20 EUR, got %v", limit)
+	}
+	if len(l.Statuses()) != 1 {
+		t.Errorf("expected one status, got %v", l.Statuses())
+	}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"lessons:\n  - visibility: \"PUBLIC\"\n", "lessons[0]: tenant_id is required"},
+		{"lessons:\n  - tenant_id: \"t\"\n    status: \"OPEN\"\n", `lessons[0]: status: unknown value "OPEN"`},
+		{"lessons:\n  - tenant_id: \"t\"\n    max_price: \"cheap\"\n", "lessons[0]: max_price:"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeTempFile(t, []byte(tt.content)))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected error containing %q, got %v", tt.want, err)
+		}
+	}
+}
+
+func TestLoad_Where(t *testing.T) {
+	content := []byte(`tournaments:
+  - tenant_id: "tenant-1"
+    where: 'start.weekday in ["Sat", "Sun"] && free_places >= 2'
+classes:
+  - tenant_id: "tenant-1"
+`)
+	cfg, err := Load(writeTempFile(t, content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Tournaments[0].WhereExpr == nil {
+		t.Error("expected the where expression to be compiled")
+	}
+	if cfg.Classes[0].WhereExpr != nil {
+		t.Error("expected no program without where")
+	}
+
+	_, err = Load(writeTempFile(t, []byte(`classes:
+  - tenant_id: "tenant-1"
+  - tenant_id: "tenant-2"
+    where: 'free_places >= 2 && coach == "Ana"'
+`)))
+	want := `classes[1]: where: 1:21: unknown field "coach"`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error containing %q, got %v", want, err)
+	}
+}
+
+func TestLoad_MatchModes(t *testing.T) {
+	cfg, err := Load(writeTempFile(t, []byte(`classes:
+  - tenant_id: "tenant-1"
+    blacklist: ["men"]
+    blacklist_mode: "word"
+    course_names: ['^adult\b']
+    course_names_mode: "regex"
+`)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Classes[0].BlacklistMode != MatchWord || cfg.Classes[0].PlayerNameMode.Or(MatchWord) != MatchWord {
+		t.Errorf("unexpected modes %+v", cfg.Classes[0])
+	}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"tournaments:\n  - tenant_id: \"t\"\n    blacklist_mode: \"words\"\n", `tournaments[0]: blacklist_mode: unknown value "words"`},
+		{"classes:\n  - tenant_id: \"t\"\n    coach_names: [\"(ana\"]\n    coach_names_mode: \"regex\"\n", "classes[0]: coach_names: error parsing regexp"},
+		{"coaches:\n  - tenant_id: \"t\"\n    coach_names_mode: \"exact\"\n", `coaches[0]: coach_names_mode: unknown value "exact"`},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeTempFile(t, []byte(tt.content)))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected error containing %q, got %v", tt.want, err)
+		}
+	}
+}
+
+func TestLoad_ClassSchedule(t *testing.T) {
+	cfg, err := Load(writeTempFile(t, []byte(`classes:
+  - tenant_id: "tenant-1"
+    weekdays: ["Tue", "thursday"]
+    time_windows: [{start: "18:00", end: "22:00"}]
+    from: "today"
+    until: "+3w"
+    min_lead_time: "2h"
+`)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cl := cfg.Classes[0]
+	if !cl.IncludesWeekday(time.Thursday) || cl.IncludesWeekday(time.Wednesday) {
+		t.Errorf("unexpected weekdays %v", cl.Weekdays)
+	}
+	today := time.Date(2026, 4, 13, 23, 30, 0, 0, time.UTC)
+	if from, until := cl.DateRange(today); from != "2026-04-13" || until != "2026-05-04" {
+		t.Errorf("expected 2026-04-13 to 2026-05-04, got %s to %s", from, until)
+	}
+	if cl.MinLeadTime != 2*time.Hour {
+		t.Errorf("expected min_lead_time 2h, got %s", cl.MinLeadTime)
+	}
+
+	tests := []struct {
+		options string
+		want    string
+	}{
+		{`weekdays: ["Tues"]`, `classes[0]: weekdays: unknown day "Tues"`},
+		{`time_windows: [{start: "20:00", end: "18:00"}]`, "classes[0]: time_windows[0]: start 20:00 is not before end 18:00"},
+		{`time_windows: [{start: "6pm", end: "22:00"}]`, "classes[0]: time_windows[0]: start:"},
+		{`until: "+3m"`, "classes[0]: until:"},
+		{`from: "2026-05-01"` + "\n    until: \"2026-04-01\"", "classes[0]: from 2026-05-01 is after until 2026-04-01"},
+		{`min_lead_time: "-1h"`, "classes[0]: min_lead_time must not be negative"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeTempFile(t, []byte("classes:\n  - tenant_id: \"t\"\n    "+tt.options+"\n")))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.options, tt.want, err)
+		}
+	}
+}
+
+func TestLoad_Me(t *testing.T) {
+	cfg, err := Load(writeTempFile(t, []byte(`me:
+  user_id: "1001"
+friends: ["2002", "3003"]
+matches:
+  - tenant_ids: ["tenant-1"]
+    skip_joined: true
+lessons:
+  - tenant_id: "tenant-1"
+`)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Matches[0].SkipJoined || cfg.Matches[0].UserID != "1001" || cfg.Lessons[0].UserID != "1001" {
+		t.Errorf("expected user 1001 on every filter, got %+v and %+v", cfg.Matches[0].Identity, cfg.Lessons[0].Identity)
+	}
+	if len(cfg.Friends) != 2 {
+		t.Errorf("expected 2 friends, got %v", cfg.Friends)
+	}
+
+	cfg, err = Load(writeTempFile(t, []byte("me: {from_token: true}\nclasses:\n  - tenant_id: \"t\"\n    skip_joined: true\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Classes[0].UserID != "" {
+		t.Errorf("expected no user ID before the token is read, got %q", cfg.Classes[0].UserID)
+	}
+	cfg.SetUserID("1001")
+	if cfg.Classes[0].UserID != "1001" {
+		t.Errorf("expected SetUserID to reach the class filter, got %q", cfg.Classes[0].UserID)
+	}
+
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"me: {user_id: \"1\", from_token: true}\nclasses: [{tenant_id: t}]\n", "me: set user_id or from_token, not both"},
+		{"friends: [\"2\", \" \"]\nclasses: [{tenant_id: t}]\n", "friends[1]: user ID must not be empty"},
+		{"tournaments: [{tenant_id: t, skip_joined: true}]\n", "tournaments[0]: skip_joined requires me.user_id or me.from_token"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeTempFile(t, []byte(tt.config)))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected error containing %q, got %v", tt.want, err)
+		}
+	}
+}
+
+// writeTempFiles writes files (name to content) into one temp dir and
+// returns the dir.
+func writeTempFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("writing temp file: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLoad_IncludeDefaultsProfiles(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"club.yaml": `defaults:
+  classes:
+    tenant_id: "club"
+    status: "PENDING"
+    coach_names: ["Deniz"]
+locations:
+  home: {lat: 52.52, lon: 13.405}
+classes:
+  - player_name: "Shared"
+`,
+		"config.yaml": `include: ["club.yaml"]
+locations:
+  home: {lat: 52.0}
+classes:
+  - player_name: "Taras S."
+  - tenant_id: "other"
+    coach_names: ["Kate"]
+profiles:
+  karina:
+    classes:
+      - player_name: "Karina P"
+    rank_by: "home"
+`,
+	})
+	path := filepath.Join(dir, "config.yaml")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Included filters come first; every filter gets the defaults it
+	// doesn't override.
+	var got []string
+	for _, cl := range cfg.Classes {
+		got = append(got, cl.TenantID+"/"+cl.PlayerName+"/"+strings.Join(cl.CoachNames, ","))
+	}
+	want := []string{"club/Shared/Deniz", "club/Taras S./Deniz", "other//Kate"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected classes %v, got %v", want, got)
+	}
+	if cfg.Classes[1].Status != "PENDING" {
+		t.Errorf("expected the default status, got %q", cfg.Classes[1].Status)
+	}
+	// Mappings merge key by key, later files winning.
+	if home := cfg.Locations["home"]; home.Lat != 52.0 || home.Lon != 13.405 {
+		t.Errorf("expected home merged to 52.0,13.405, got %+v", home)
+	}
+	if cfg.RankBy != "" {
+		t.Errorf("expected no profile without -profile, got rank_by %q", cfg.RankBy)
+	}
+
+	cfg, err = LoadProfile(path, "karina")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Classes) != 4 || cfg.Classes[3].PlayerName != "Karina P" || cfg.Classes[3].TenantID != "club" || cfg.RankBy != "home" {
+		t.Errorf("expected the karina profile merged in with defaults, got %+v", cfg)
+	}
+
+	if _, err := LoadProfile(path, "kate"); err == nil || !strings.Contains(err.Error(), `config.yaml:8: profile "kate" not found (profiles: karina)`) {
+		t.Errorf("expected an unknown profile error, got %v", err)
+	}
+}
+
+func TestLoad_ErrorPositions(t *testing.T) {
+	dir := writeTempFiles(t, map[string]string{
+		"club.yaml":        "defaults:\n  classes: {tenant_id: club}\n",
+		"where.yaml":       "include: club.yaml\nclasses:\n  - tenant_id: \"a\"\n    where: \"fre > 1\"\n",
+		"bad-type.yaml":    "classes:\n  - tenant_id: \"a\"\n    show_only_available: maybe\n",
+		"bad-default.yaml": "defaults:\n  class: {tenant_id: club}\nclasses: [{tenant_id: a}]\n",
+		"same-line.yaml":   "classes:\n  - tenant_id: \"a\"\n    show_only_available: true\n    weekdays: [Mon]\n",
+		"cycle-a.yaml":     "include: cycle-b.yaml\n",
+		"cycle-b.yaml":     "include: cycle-a.yaml\n",
+	})
+	tests := []struct {
+		config string
+		want   string
+	}{
+		// Validation errors point at the option, in the file it came from.
+		{"include: where.yaml\nclasses:\n  - {}\n", "where.yaml:4: invalid config: classes[0]: where: 1:1: unknown field"},
+		{"include: club.yaml\nclasses:\n  - {}\n  - {tenant_id: b, weekdays: [Tues]}\n", "config.yaml:4: invalid config: classes[1]: weekdays: unknown day"},
+		{"include: bad-type.yaml\n", "bad-type.yaml:3: cannot unmarshal !!str `maybe` into bool"},
+		{"include: bad-default.yaml\n", `bad-default.yaml:2: defaults: unknown section "class"`},
+		// Merged files with options on the same line are told apart.
+		{"include: same-line.yaml\nclasses:\n  - tenant_id: \"b\"\n    show_only_available: maybe\n", "config.yaml:4: cannot unmarshal !!str `maybe` into bool"},
+		{"include: same-line.yaml\nclasses:\n  - tenant_id: \"b\"\n    weekdays: [Tues]\n", "config.yaml:4: invalid config: classes[1]: weekdays: unknown day"},
+		{"include: same-line.yaml\ntenants: {a: {id: x}}\nclasses:\n  - {tenant: b}\n", `config.yaml:4: invalid config: classes[1]: tenant: unknown alias "b"`},
+		{"include: missing.yaml\n", "config.yaml:1: include " + filepath.Join(dir, "missing.yaml") + ": reading config file"},
+		{"include: cycle-a.yaml\n", "include cycle: "},
+		{"include: [1, [2]]\n", "config.yaml:1: include must be a file name or a list of them"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, "config.yaml")
+		if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Load(path)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.config, tt.want, err)
+		}
+	}
+}
+
+func TestLoad_Tenants(t *testing.T) {
+	cfg, err := Load(writeTempFile(t, []byte(`timezone: "UTC"
+tenants:
+  charlotte:
+    id: "8b818dae"
+    name: "Charlotte-Mitte"
+    timezone: "Europe/Berlin"
+  pbc:
+    id: "9fea856e"
+courts:
+  - tenant: "charlotte"
+    sport_id: "PADEL"
+    time_windows: [{start: "17:00", end: "20:00"}]
+  - tenant: "pbc"
+    sport_id: "PADEL"
+    time_windows: [{start: "17:00", end: "20:00"}]
+matches:
+  - tenant_ids: ["other"]
+    tenants: ["charlotte", "pbc"]
+`)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Courts[0].TenantID != "8b818dae" || cfg.Courts[1].TenantID != "9fea856e" {
+		t.Errorf("expected aliases resolved to IDs, got %q and %q", cfg.Courts[0].TenantID, cfg.Courts[1].TenantID)
+	}
+	if want := []string{"other", "8b818dae", "9fea856e"}; !slices.Equal(cfg.Matches[0].TenantIDs, want) {
+		t.Errorf("expected tenant IDs %v, got %v", want, cfg.Matches[0].TenantIDs)
+	}
+	if tz := cfg.CourtTimezone(cfg.Courts[0]); tz != "Europe/Berlin" {
+		t.Errorf("expected the tenant's timezone, got %q", tz)
+	}
+	if tz := cfg.CourtTimezone(cfg.Courts[1]); tz != "UTC" {
+		t.Errorf("expected the config-wide timezone, got %q", tz)
+	}
+	for id, want := range map[string]string{"8b818dae": "Charlotte-Mitte", "9fea856e": "pbc", "unknown": "unknown"} {
+		if got := cfg.TenantName(id); got != want {
+			t.Errorf("TenantName(%q): expected %q, got %q", id, want, got)
+		}
+	}
+
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"tenants: {a: {id: x}}\nclasses: [{tenant: b}]\n", `config.yaml:2: invalid config: classes[0]: tenant: unknown alias "b" (tenants: a)`},
+		{"tenants: {a: {id: x}}\nclasses: [{tenant: a, tenant_id: x}]\n", "classes[0]: tenant: set tenant or tenant_id, not both"},
+		{"tenants: {a: {name: A}}\nclasses: [{tenant_id: x}]\n", "tenants: a: id is required"},
+		{"tenants: {a: {id: x, timezone: Mars/Olympus}}\nclasses: [{tenant_id: x}]\n", "tenants: a: timezone:"},
+		{"matches: [{tenants: [a]}]\n", `matches[0]: tenants[0]: tenant: unknown alias "a" (no tenants configured)`},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeTempFile(t, []byte(tt.config)))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.config, tt.want, err)
+		}
+	}
+}
+
+func TestValidateLeavesConfigUnresolved(t *testing.T) {
+	cfg := Config{
+		Tenants: map[string]Tenant{"charlotte": {ID: "8b818dae"}},
+		Classes: []ClassFilter{{Tenant: "charlotte", Where: "free_places > 0"}},
+		Matches: []MatchFilter{{Tenants: []string{"charlotte"}}},
+	}
+	if err := cfg.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cl := cfg.Classes[0]; cl.TenantID != "" || cl.WhereExpr != nil || len(cfg.Matches[0].TenantIDs) != 0 {
+		t.Fatalf("expected validate to leave the config as it was, got %+v and %+v", cl, cfg.Matches[0])
+	}
+
+	if err := cfg.resolve(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cl := cfg.Classes[0]; cl.TenantID != "8b818dae" || cl.WhereExpr == nil {
+		t.Errorf("expected the alias resolved and where compiled, got %+v", cl)
+	}
+	if want := []string{"8b818dae"}; !slices.Equal(cfg.Matches[0].TenantIDs, want) {
+		t.Errorf("expected tenant IDs %v, got %v", want, cfg.Matches[0].TenantIDs)
+	}
+}
+
+func TestLoad_DefaultTenantOverridden(t *testing.T) {
+	shared, err := filepath.Abs("../../configs/shared/defaults.yaml")
+	if err != nil {
+		t.Fatalf("resolving shared defaults: %v", err)
+	}
+	cfg, err := Load(writeTempFile(t, []byte(`include: "`+shared+`"
+defaults:
+  matches:
+    tenants: ["charlotte"]
+classes:
+  - tenant_id: "other"
+  - {}
+matches:
+  - tenant_ids: ["other"]
+`)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// tenant_id replaces the default tenant alias instead of clashing with it.
+	if got := cfg.Classes[0].TenantID; got != "other" {
+		t.Errorf("expected the filter's own tenant_id, got %q", got)
+	}
+	if got := cfg.Classes[1].TenantID; got != "8b818dae-aacb-4ea3-aa7b-0e77b1149c85" {
+		t.Errorf("expected the default tenant, got %q", got)
+	}
+	if want := []string{"other"}; !slices.Equal(cfg.Matches[0].TenantIDs, want) {
+		t.Errorf("expected tenant IDs %v, got %v", want, cfg.Matches[0].TenantIDs)
+	}
+}
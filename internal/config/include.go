@@ -0,0 +1,359 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sections are the filter lists a defaults: block can fill in.
+var sections = []string{"tournaments", "classes", "courts", "coaches", "matches", "lessons"}
+
+// sameOption groups the keys that set one option in different ways. A
+// filter setting any of them inherits none of the others from defaults:,
+// so an explicit tenant_id replaces a default tenant alias.
+var sameOption = [][]string{
+	{"tenant", "tenant_id"},
+	{"tenants", "tenant_ids"},
+}
+
+// optionKeys returns the keys that set the same option as name, name
+// included.
+func optionKeys(name string) []string {
+	for _, group := range sameOption {
+		if slices.Contains(group, name) {
+			return group
+		}
+	}
+	return []string{name}
+}
+
+// loader reads a config file and the files it includes into one YAML tree,
+// remembering which file each node came from so errors can point there.
+//
+// Files merge in a fixed order: each include, in the order listed (its own
+// includes first), then the including file itself. Mappings merge key by
+// key, lists concatenate with earlier files' items first, and any other
+// value from a later file replaces an earlier one. A selected profile is
+// merged over the result the same way, and defaults are applied last.
+type loader struct {
+	path    string // the file Load was given
+	origins map[*yaml.Node]string
+	stack   []string // files being loaded, to catch include cycles
+}
+
+func newLoader(path string) *loader {
+	return &loader{path: path, origins: make(map[*yaml.Node]string)}
+}
+
+// load parses path and merges it over the files it includes.
+func (l *loader) load(path string) (*yaml.Node, error) {
+	if slices.Contains(l.stack, path) {
+		return nil, fmt.Errorf("include cycle: %s", strings.Join(append(l.stack, path), " -> "))
+	}
+	l.stack = append(l.stack, path)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+	l.track(root, path)
+	if root.Kind != yaml.MappingNode {
+		return nil, l.errorAt(root, fmt.Errorf("config must be a mapping of sections"))
+	}
+
+	key, includes := takeKey(root, "include")
+	if includes == nil {
+		return root, nil
+	}
+	files, err := includeList(includes)
+	if err != nil {
+		return nil, l.errorAt(key, err)
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, file := range files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		included, err := l.load(file)
+		if err != nil {
+			return nil, l.errorAt(key, fmt.Errorf("include %s: %w", file, err))
+		}
+		merge(merged, included)
+	}
+	merge(merged, root)
+	return merged, nil
+}
+
+// includeList reads include: as one file name or a list of them.
+func includeList(n *yaml.Node) ([]string, error) {
+	if n.Kind == yaml.ScalarNode {
+		return []string{n.Value}, nil
+	}
+	var files []string
+	if n.Kind != yaml.SequenceNode || n.Decode(&files) != nil {
+		return nil, fmt.Errorf("include must be a file name or a list of them")
+	}
+	return files, nil
+}
+
+// applyProfile merges the named entry of profiles: over root. An empty name
+// selects none; profiles: is dropped either way.
+func (l *loader) applyProfile(root *yaml.Node, name string) error {
+	key, profiles := takeKey(root, "profiles")
+	if name == "" {
+		return nil
+	}
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: profile %q: no profiles configured", l.path, name)
+	}
+	_, p := lookup(profiles, name)
+	if p == nil {
+		return l.errorAt(key, fmt.Errorf("profile %q not found (profiles: %s)", name, strings.Join(keys(profiles), ", ")))
+	}
+	if p.Kind != yaml.MappingNode {
+		return l.errorAt(p, fmt.Errorf("profiles: %s must be a mapping of sections", name))
+	}
+	for _, nested := range []string{"include", "profiles"} {
+		if k, _ := lookup(p, nested); k != nil {
+			return l.errorAt(k, fmt.Errorf("profiles: %s: %s is only allowed at the top level", name, nested))
+		}
+	}
+	merge(root, p)
+	return nil
+}
+
+// applyDefaults gives every filter of a section the options from that
+// section's defaults: entry that the filter doesn't set itself. A filter's
+// own value replaces the default outright, lists included, and so does any
+// other key for the same option (see sameOption).
+func (l *loader) applyDefaults(root *yaml.Node) error {
+	key, defaults := takeKey(root, "defaults")
+	if defaults == nil {
+		return nil
+	}
+	if defaults.Kind != yaml.MappingNode {
+		return l.errorAt(key, fmt.Errorf("defaults must map sections to options"))
+	}
+	for i := 0; i+1 < len(defaults.Content); i += 2 {
+		section, def := defaults.Content[i], defaults.Content[i+1]
+		if !slices.Contains(sections, section.Value) {
+			return l.errorAt(section, fmt.Errorf("defaults: unknown section %q (sections: %s)", section.Value, strings.Join(sections, ", ")))
+		}
+		if def.Kind != yaml.MappingNode {
+			return l.errorAt(def, fmt.Errorf("defaults: %s must be a mapping of options", section.Value))
+		}
+
+		_, filters := lookup(root, section.Value)
+		if filters == nil || filters.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, f := range filters.Content {
+			if f.Kind != yaml.MappingNode {
+				continue // decoding reports it
+			}
+			var inherited []*yaml.Node
+			for j := 0; j+1 < len(def.Content); j += 2 {
+				if !setsOption(f, def.Content[j].Value) {
+					inherited = append(inherited, def.Content[j], def.Content[j+1])
+				}
+			}
+			f.Content = append(inherited, f.Content...)
+		}
+	}
+	return nil
+}
+
+// setsOption reports whether filter f sets the option name is a key for.
+func setsOption(f *yaml.Node, name string) bool {
+	for _, k := range optionKeys(name) {
+		if key, _ := lookup(f, k); key != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// merge overlays src onto dst, both mappings: nested mappings merge key by
+// key, lists concatenate (dst's items first), and any other value in src
+// replaces dst's.
+func merge(dst, src *yaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], src.Content[i+1]
+		j := keyIndex(dst, key.Value)
+		if j < 0 {
+			dst.Content = append(dst.Content, key, val)
+			continue
+		}
+		cur := dst.Content[j+1]
+		switch {
+		case cur.Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+			merge(cur, val)
+		case cur.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+			cur.Content = append(cur.Content, val.Content...)
+		default:
+			dst.Content[j+1] = val
+		}
+	}
+}
+
+func keyIndex(m *yaml.Node, name string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookup returns the key and value nodes for name in mapping m, or nils.
+func lookup(m *yaml.Node, name string) (key, val *yaml.Node) {
+	if m.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	if i := keyIndex(m, name); i >= 0 {
+		return m.Content[i], m.Content[i+1]
+	}
+	return nil, nil
+}
+
+// takeKey removes name from mapping m, returning its key and value nodes.
+func takeKey(m *yaml.Node, name string) (key, val *yaml.Node) {
+	i := keyIndex(m, name)
+	if i < 0 {
+		return nil, nil
+	}
+	key, val = m.Content[i], m.Content[i+1]
+	m.Content = slices.Delete(m.Content, i, i+2)
+	return key, val
+}
+
+func keys(m *yaml.Node) []string {
+	var names []string
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		names = append(names, m.Content[i].Value)
+	}
+	return names
+}
+
+// track records path as the origin of n and everything under it.
+func (l *loader) track(n *yaml.Node, path string) {
+	l.origins[n] = path
+	for _, c := range n.Content {
+		l.track(c, path)
+	}
+}
+
+// position returns "file:line" for n, or just the loaded file if n is nil
+// or wasn't read from one.
+func (l *loader) position(n *yaml.Node) string {
+	if n == nil {
+		return l.path
+	}
+	file, ok := l.origins[n]
+	if !ok {
+		return l.path
+	}
+	return fmt.Sprintf("%s:%d", file, n.Line)
+}
+
+func (l *loader) errorAt(n *yaml.Node, err error) error {
+	return fmt.Errorf("%s: %w", l.position(n), err)
+}
+
+// locate finds the node a validation error is about by following the
+// pathErrors it wraps down the merged tree, as far as the tree goes. It
+// returns nil for errors about the config as a whole.
+func locate(root *yaml.Node, err error) *yaml.Node {
+	var found *yaml.Node
+	node := root
+	for {
+		var pe *pathError
+		if !errors.As(err, &pe) {
+			return found
+		}
+		key, val := lookup(node, pe.key)
+		if key == nil {
+			return found
+		}
+		found, node = key, val
+		if pe.index >= 0 {
+			if val.Kind != yaml.SequenceNode || pe.index >= len(val.Content) {
+				return found
+			}
+			found, node = val.Content[pe.index], val.Content[pe.index]
+		}
+		err = pe.err
+	}
+}
+
+// decode decodes the merged tree into cfg. yaml.v3 reports type errors by
+// line number alone, which can't tell merged files apart, so on failure
+// each option is decoded again on its own to find the nodes at fault and
+// report them as "file:line".
+func (l *loader) decode(root *yaml.Node, cfg *Config) error {
+	err := root.Decode(cfg)
+	var te *yaml.TypeError
+	if !errors.As(err, &te) {
+		return err
+	}
+	var located []string
+	l.findBadNodes(root, func(n *yaml.Node) *yaml.Node { return n }, &located)
+	if len(located) == 0 {
+		return err
+	}
+	return &yaml.TypeError{Errors: located}
+}
+
+// findBadNodes decodes each entry of n, a mapping or a list, in a tree
+// holding only the path to it (built by wrap), and descends into the
+// entries that fail until it reaches the nodes at fault. Their errors are
+// appended to located, prefixed with the node's position.
+func (l *loader) findBadNodes(n *yaml.Node, wrap func(*yaml.Node) *yaml.Node, located *[]string) {
+	try := func(entry []*yaml.Node, bad *yaml.Node) {
+		kind, tag := n.Kind, n.Tag
+		var te *yaml.TypeError
+		if !errors.As(wrap(&yaml.Node{Kind: kind, Tag: tag, Content: entry}).Decode(new(Config)), &te) {
+			return
+		}
+		if bad.Kind == yaml.MappingNode || bad.Kind == yaml.SequenceNode {
+			before := len(*located)
+			l.findBadNodes(bad, func(c *yaml.Node) *yaml.Node {
+				return wrap(&yaml.Node{Kind: kind, Tag: tag, Content: append(entry[:len(entry)-1:len(entry)-1], c)})
+			}, located)
+			if len(*located) > before {
+				return
+			}
+		}
+		for _, e := range te.Errors {
+			msg, _ := strings.CutPrefix(e, fmt.Sprintf("line %d: ", bad.Line))
+			*located = append(*located, l.position(bad)+": "+msg)
+		}
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			try(n.Content[i:i+2], n.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			try([]*yaml.Node{item}, item)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package expr implements the small expression language behind a filter's
+// where: option, e.g.
+//
+//	start.weekday in ["Tue", "Thu"] && free_places >= 2 && !(name ~ "(?i)ladies")
+//
+// Expressions are compiled once against a Schema, the documented field set
+// of one model (see Tournaments, Classes, Matches and Lessons), so unknown
+// fields, type mismatches and bad regular expressions are reported with
+// their line and column when the config is loaded rather than on every run.
+//
+// The language has:
+//
+//   - literals: "double-quoted" strings with Go escapes, 'single-quoted'
+//     strings taken as-is (handy for regular expressions), numbers, true,
+//     false, and lists such as ["Tue", "Thu"] or [1, 2]
+//   - comparisons: == != < <= > >= on strings and numbers (== and != also on
+//     booleans); strings compare exactly and byte-wise
+//   - membership: x in [...] for a string or number, or "Ana" in coaches for
+//     list fields
+//   - regular expressions: field ~ "re" and field !~ "re", where the right
+//     side is a literal in Go's regexp syntax; on a list field ~ matches if
+//     any element does
+//   - logic: ! && || and parentheses, with the usual precedence
+//
+// Time fields such as start have no value of their own and are read through
+// a suffix: start.date ("2006-01-02"), start.time ("15:04"), start.weekday
+// ("Mon" to "Sun"), start.hour and start.minute, all in the club's time
+// zone.
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Program is a compiled expression for models of type T.
+type Program[T any] struct {
+	src  string
+	eval func(T) bool
+}
+
+// Compile parses src and checks it against schema. The result must be a
+// boolean expression.
+func Compile[T any](src string, schema Schema[T]) (*Program[T], error) {
+	p := &parser[T]{src: src, schema: schema}
+	if err := p.init(); err != nil {
+		return nil, err
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf(p.tok.pos, "unexpected %s", p.tok)
+	}
+	if n.kind != KindBool {
+		return nil, p.errorf(n.pos, "expression must be true or false, not a %s", n.kind)
+	}
+
+	eval := n.eval
+	return &Program[T]{src: src, eval: func(v T) bool { return eval(v).b }}, nil
+}
+
+// Match reports whether v satisfies the expression. A nil Program matches
+// everything, so filters without a where: need no special case.
+func (p *Program[T]) Match(v T) bool {
+	if p == nil {
+		return true
+	}
+	return p.eval(v)
+}
+
+// String returns the source the program was compiled from.
+func (p *Program[T]) String() string {
+	if p == nil {
+		return ""
+	}
+	return p.src
+}
+
+// Error is a compile error at a position in the source.
+type Error struct {
+	Line, Col int // 1-based
+	Msg       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Col, e.Msg)
+}
+
+// newError returns an Error for the byte offset pos in src.
+func newError(src string, pos int, msg string) *Error {
+	before := src[:min(pos, len(src))]
+	line := strings.Count(before, "\n") + 1
+	col := len(before) - strings.LastIndex(before, "\n")
+	return &Error{Line: line, Col: col, Msg: msg}
+}
@@ -0,0 +1,117 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+	"github.com/rafa-garcia/go-playtomic-api/models/fixtures"
+)
+
+func TestProgramMatch(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	// Fixture clubs are in Berlin; times are read in the club's zone.
+	tuesday := time.Date(2026, 4, 14, 18, 30, 0, 0, berlin)
+	c := fixtures.Class().WithCoach("Ana Lopez").WithRegistrations(1).Starting(tuesday).Build()
+	c.CourseSummary.Name = "Intermediate Drills"
+
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{`start.weekday in ["Tue", "Thu"] && free_places >= 2 && !(name ~ "(?i)ladies")`, true},
+		{`start.weekday == "Wed"`, false},
+		{`start.time >= "18:00" && start.hour < 19 && start.minute == 30`, true},
+		{`start.date == "2026-04-14"`, true},
+		{`free_places > 3 || registrations == 1`, true},
+		{`"Ana Lopez" in coaches`, true},
+		{`coaches ~ '^Ben'`, false},
+		{`coaches !~ '^Ben'`, true},
+		{`name ~ '(?i)\bdrills\b'`, true},
+		{`free_places in [1, 3]`, true},
+		{`!cancelled && type == "COURSE"`, true},
+		{`cancelled == false`, true},
+		{`club == "Other Club" || (price > 0 && price <= 20)`, true},
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.src, Classes)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", tt.src, err)
+			continue
+		}
+		if got := p.Match(c); got != tt.want {
+			t.Errorf("%q: expected %v, got %v", tt.src, tt.want, got)
+		}
+	}
+
+	var none *Program[models.Class]
+	if !none.Match(c) {
+		t.Error("expected a nil program to match everything")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`fre_places >= 2`, `1:1: unknown field "fre_places"`},
+		{`free_places >= "2"`, `1:13: cannot compare number with string`},
+		{`name ~ "(unclosed"`, `1:8: invalid regular expression`},
+		{`name ~ club`, `1:8: ~ needs a quoted regular expression`},
+		{`start == "Tue"`, `1:1: start is a time; use start.date`},
+		{`name.length > 2`, `1:1: name is a string and has no .length`},
+		{`free_places`, `1:1: expression must be true or false, not a number`},
+		{`free_places >= 2 && name`, `1:21: && needs true or false on both sides, not a string`},
+		{"free_places >= 2 &&\n  start.weekday = \"Tue\"", `2:17: unexpected '=' (did you mean "=="?)`},
+		{`start.weekday in ["Tue", 2]`, `1:26: lists can't mix strings and numbers`},
+		{`name in "Tue"`, `1:9: in needs a list on the right`},
+		{`(free_places > 1`, `1:17: expected ")", found end of expression`},
+		{`name == "unterminated`, `1:9: unterminated string`},
+		{`free_places > 1 free_places`, `1:17: unexpected "free_places"`},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.src, Classes)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Compile(%q): expected error starting %q, got %v", tt.src, tt.want, err)
+		}
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	m := fixtures.Match().WithPlayers(2).Levels(2.5, 4).Build()
+	p, err := Compile(`min_level <= 3 && (max_level == 0 || max_level >= 3) && free_places == 2 && gender == "MIXED"`, Matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Match(m) {
+		t.Error("expected the match to fit")
+	}
+
+	l := fixtures.Lesson().WithTags("drills").WithLevel("Intermediate").Build()
+	lp, err := Compile(`tags ~ "drill" && level != "" && closes.date < start.date && status == "REGISTRATION_OPEN"`, Lessons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lp.Match(l) {
+		t.Error("expected the lesson to match")
+	}
+
+	tr := fixtures.Tournament().WithTeam("Ana", "Ben").Build()
+	tp, err := Compile(`"Ana" in players && free_places == 6 && visibility == "PUBLIC"`, Tournaments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tp.Match(tr) {
+		t.Error("expected the tournament to match")
+	}
+
+	// Fields are per model.
+	if _, err := Compile(`tags ~ "x"`, Tournaments); err == nil {
+		t.Error("expected tags to be unknown for tournaments")
+	}
+}
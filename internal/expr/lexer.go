@@ -0,0 +1,128 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp // operators, brackets and commas; text holds the symbol
+)
+
+type token struct {
+	kind tokenKind
+	text string  // identifier, operator, or unquoted string
+	num  float64 // for tokNumber
+	pos  int     // byte offset in the source
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	case tokNumber:
+		return "number " + strconv.FormatFloat(t.num, 'g', -1, 64)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators lists every operator, two-character ones first so "<=" isn't
+// read as "<" followed by "=".
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "!~", "<", ">", "!", "~", "(", ")", "[", "]", ","}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+// next returns the next token, or an error message and its position.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '"' || c == '\'':
+		return l.string(c)
+	case c >= '0' && c <= '9' || c == '-' || c == '.':
+		return l.number()
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && isIdentByte(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokIdent, text: l.src[start:l.pos], pos: start}, nil
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokOp, text: op, pos: start}, nil
+		}
+	}
+	if c == '=' || c == '&' || c == '|' {
+		return token{}, newError(l.src, start, fmt.Sprintf("unexpected %q (did you mean %q?)", c, strings.Repeat(string(c), 2)))
+	}
+	return token{}, newError(l.src, start, fmt.Sprintf("unexpected %q", c))
+}
+
+func (l *lexer) string(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) && l.src[l.pos] != quote {
+		if quote == '"' && l.src[l.pos] == '\\' {
+			l.pos++
+		}
+		l.pos++
+	}
+	if l.pos >= len(l.src) {
+		return token{}, newError(l.src, start, "unterminated string")
+	}
+	l.pos++
+
+	raw := l.src[start:l.pos]
+	if quote == '\'' {
+		return token{kind: tokString, text: raw[1 : len(raw)-1], pos: start}, nil
+	}
+	s, err := strconv.Unquote(raw)
+	if err != nil {
+		return token{}, newError(l.src, start, fmt.Sprintf("invalid string %s (use single quotes for backslashes in regular expressions)", raw))
+	}
+	return token{kind: tokString, text: s, pos: start}, nil
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) && (l.src[l.pos] >= '0' && l.src[l.pos] <= '9' || l.src[l.pos] == '.') {
+		l.pos++
+	}
+	n, err := strconv.ParseFloat(l.src[start:l.pos], 64)
+	if err != nil {
+		return token{}, newError(l.src, start, fmt.Sprintf("invalid number %q", l.src[start:l.pos]))
+	}
+	return token{kind: tokNumber, num: n, pos: start}, nil
+}
+
+// Field names are ASCII, so identifiers are too.
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || isLetter(c)
+}
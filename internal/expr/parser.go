@@ -0,0 +1,344 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// node is a type-checked subexpression compiled to a closure.
+type node[T any] struct {
+	kind Kind
+	pos  int
+	eval func(T) value
+	lit  *value // set for literals, which some operators require
+}
+
+// parser is a recursive-descent parser that type-checks and compiles as it
+// goes, so every error carries the position of the offending token.
+//
+//	or      = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | compare
+//	compare = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "!~" | "in" ) operand ]
+//	operand = field | string | number | "true" | "false" | list | "(" or ")"
+//	list    = "[" [ literal { "," literal } ] "]"
+type parser[T any] struct {
+	src    string
+	schema Schema[T]
+	lex    lexer
+	tok    token
+}
+
+func (p *parser[T]) init() error {
+	p.lex = lexer{src: p.src}
+	return p.advance()
+}
+
+func (p *parser[T]) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser[T]) errorf(pos int, format string, args ...interface{}) error {
+	return newError(p.src, pos, fmt.Sprintf(format, args...))
+}
+
+// is reports whether the current token is the operator or keyword s.
+func (p *parser[T]) is(s string) bool {
+	return (p.tok.kind == tokOp || p.tok.kind == tokIdent) && p.tok.text == s
+}
+
+func (p *parser[T]) expect(op string) error {
+	if !p.is(op) {
+		return p.errorf(p.tok.pos, "expected %q, found %s", op, p.tok)
+	}
+	return p.advance()
+}
+
+func (p *parser[T]) parseOr() (*node[T], error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("||") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.wantBool(left, "||"); err != nil {
+			return nil, err
+		}
+		if err := p.wantBool(right, "||"); err != nil {
+			return nil, err
+		}
+		l, r := left.eval, right.eval
+		left = &node[T]{kind: KindBool, pos: left.pos, eval: func(v T) value { return value{b: l(v).b || r(v).b} }}
+	}
+	return left, nil
+}
+
+func (p *parser[T]) parseAnd() (*node[T], error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("&&") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.wantBool(left, "&&"); err != nil {
+			return nil, err
+		}
+		if err := p.wantBool(right, "&&"); err != nil {
+			return nil, err
+		}
+		l, r := left.eval, right.eval
+		left = &node[T]{kind: KindBool, pos: left.pos, eval: func(v T) value { return value{b: l(v).b && r(v).b} }}
+	}
+	return left, nil
+}
+
+func (p *parser[T]) parseUnary() (*node[T], error) {
+	if !p.is("!") {
+		return p.parseCompare()
+	}
+	pos := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.wantBool(operand, "!"); err != nil {
+		return nil, err
+	}
+	eval := operand.eval
+	return &node[T]{kind: KindBool, pos: pos, eval: func(v T) value { return value{b: !eval(v).b} }}, nil
+}
+
+func (p *parser[T]) wantBool(n *node[T], op string) error {
+	if n.kind != KindBool {
+		return p.errorf(n.pos, "%s needs true or false on both sides, not a %s", op, n.kind)
+	}
+	return nil
+}
+
+var compareOps = []string{"==", "!=", "<", "<=", ">", ">=", "~", "!~", "in"}
+
+func (p *parser[T]) parseCompare() (*node[T], error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(compareOps, p.is) {
+		return left, nil
+	}
+
+	op, opPos := p.tok.text, p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "~", "!~":
+		return p.compileMatch(op, opPos, left, right)
+	case "in":
+		return p.compileIn(opPos, left, right)
+	}
+	return p.compileCompare(op, opPos, left, right)
+}
+
+func (p *parser[T]) compileCompare(op string, opPos int, left, right *node[T]) (*node[T], error) {
+	if left.kind != right.kind {
+		return nil, p.errorf(opPos, "cannot compare %s with %s", left.kind, right.kind)
+	}
+	ordered := op != "==" && op != "!="
+	switch {
+	case left.kind == KindBool && ordered:
+		return nil, p.errorf(opPos, "%s doesn't apply to booleans", op)
+	case left.kind != KindString && left.kind != KindNumber && left.kind != KindBool:
+		return nil, p.errorf(opPos, "%s doesn't apply to a %s (use in or ~ for lists)", op, left.kind)
+	}
+
+	kind, l, r := left.kind, left.eval, right.eval
+	cmp := func(v T) int {
+		a, b := l(v), r(v)
+		switch kind {
+		case KindString:
+			return strings.Compare(a.s, b.s)
+		case KindNumber:
+			switch {
+			case a.n < b.n:
+				return -1
+			case a.n > b.n:
+				return 1
+			}
+			return 0
+		}
+		if a.b == b.b {
+			return 0
+		}
+		return 1
+	}
+
+	var test func(int) bool
+	switch op {
+	case "==":
+		test = func(c int) bool { return c == 0 }
+	case "!=":
+		test = func(c int) bool { return c != 0 }
+	case "<":
+		test = func(c int) bool { return c < 0 }
+	case "<=":
+		test = func(c int) bool { return c <= 0 }
+	case ">":
+		test = func(c int) bool { return c > 0 }
+	case ">=":
+		test = func(c int) bool { return c >= 0 }
+	}
+	return &node[T]{kind: KindBool, pos: left.pos, eval: func(v T) value { return value{b: test(cmp(v))} }}, nil
+}
+
+func (p *parser[T]) compileMatch(op string, opPos int, left, right *node[T]) (*node[T], error) {
+	if left.kind != KindString && left.kind != KindList {
+		return nil, p.errorf(opPos, "%s needs a string or list on the left, not a %s", op, left.kind)
+	}
+	if right.lit == nil || right.kind != KindString {
+		return nil, p.errorf(right.pos, "%s needs a quoted regular expression on the right", op)
+	}
+	re, err := regexp.Compile(right.lit.s)
+	if err != nil {
+		return nil, p.errorf(right.pos, "invalid regular expression: %v", err)
+	}
+
+	l, list, negate := left.eval, left.kind == KindList, op == "!~"
+	return &node[T]{kind: KindBool, pos: left.pos, eval: func(v T) value {
+		a := l(v)
+		matched := re.MatchString(a.s)
+		if list {
+			matched = slices.ContainsFunc(a.l, re.MatchString)
+		}
+		return value{b: matched != negate}
+	}}, nil
+}
+
+func (p *parser[T]) compileIn(opPos int, left, right *node[T]) (*node[T], error) {
+	l, r := left.eval, right.eval
+	switch {
+	case left.kind == KindString && right.kind == KindList:
+		return &node[T]{kind: KindBool, pos: left.pos, eval: func(v T) value { return value{b: slices.Contains(r(v).l, l(v).s)} }}, nil
+	case left.kind == KindNumber && right.kind == kindNumberList:
+		return &node[T]{kind: KindBool, pos: left.pos, eval: func(v T) value { return value{b: slices.Contains(r(v).nl, l(v).n)} }}, nil
+	case right.kind != KindList && right.kind != kindNumberList:
+		return nil, p.errorf(right.pos, "in needs a list on the right, not a %s", right.kind)
+	}
+	return nil, p.errorf(opPos, "cannot look for a %s in this list", left.kind)
+}
+
+func (p *parser[T]) parseOperand() (*node[T], error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokString:
+		return p.literal(KindString, value{s: tok.text})
+	case tok.kind == tokNumber:
+		return p.literal(KindNumber, value{n: tok.num})
+	case p.is("true"), p.is("false"):
+		return p.literal(KindBool, value{b: tok.text == "true"})
+	case tok.kind == tokIdent:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return p.field(tok)
+	case p.is("["):
+		return p.parseList()
+	case p.is("("):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		n.pos = tok.pos
+		return n, p.expect(")")
+	}
+	return nil, p.errorf(tok.pos, "expected a field, value or \"(\", found %s", tok)
+}
+
+func (p *parser[T]) literal(kind Kind, v value) (*node[T], error) {
+	n := &node[T]{kind: kind, pos: p.tok.pos, lit: &v, eval: func(T) value { return v }}
+	return n, p.advance()
+}
+
+func (p *parser[T]) parseList() (*node[T], error) {
+	pos := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var v value
+	kind := KindList
+	for i := 0; !p.is("]"); i++ {
+		if i > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case p.tok.kind == tokString && (i == 0 || kind == KindList):
+			v.l = append(v.l, p.tok.text)
+		case p.tok.kind == tokNumber && (i == 0 || kind == kindNumberList):
+			kind = kindNumberList
+			v.nl = append(v.nl, p.tok.num)
+		case p.tok.kind == tokString || p.tok.kind == tokNumber:
+			return nil, p.errorf(p.tok.pos, "lists can't mix strings and numbers")
+		default:
+			return nil, p.errorf(p.tok.pos, "expected a string or number in the list, found %s", p.tok)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return &node[T]{kind: kind, pos: pos, lit: &v, eval: func(T) value { return v }}, p.advance()
+}
+
+// field resolves an identifier, including time suffixes like start.weekday.
+func (p *parser[T]) field(tok token) (*node[T], error) {
+	name, suffix, hasSuffix := strings.Cut(tok.text, ".")
+	f, ok := p.schema[name]
+	if !ok {
+		return nil, p.errorf(tok.pos, "unknown field %q (fields: %s)", name, p.schema.names())
+	}
+
+	get := f.get
+	if f.Kind != KindTime {
+		if hasSuffix {
+			return nil, p.errorf(tok.pos, "%s is a %s and has no .%s", name, f.Kind, suffix)
+		}
+		return &node[T]{kind: f.Kind, pos: tok.pos, eval: get}, nil
+	}
+
+	part, ok := timeSuffixes[suffix]
+	if !ok {
+		return nil, p.errorf(tok.pos, "%s is a time; use %s.date, .time, .weekday, .hour or .minute", name, name)
+	}
+	return &node[T]{kind: part.kind, pos: tok.pos, eval: func(v T) value { return part.get(get(v).t) }}, nil
+}
@@ -0,0 +1,189 @@
+package expr
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// Kind is the type of a field or expression.
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindNumber
+	KindBool
+	KindList // a list of strings
+	KindTime // only readable through a suffix such as .weekday
+
+	kindNumberList // list literals of numbers; no field has this kind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindString:
+		return "string"
+	case KindNumber:
+		return "number"
+	case KindBool:
+		return "boolean"
+	case KindList, kindNumberList:
+		return "list"
+	case KindTime:
+		return "time"
+	}
+	return "unknown"
+}
+
+// value holds an evaluated expression; which field is set follows from the
+// expression's Kind, which Compile already checked.
+type value struct {
+	s  string
+	n  float64
+	b  bool
+	l  []string
+	nl []float64
+	t  time.Time
+}
+
+// Field is one named value a Schema exposes.
+type Field[T any] struct {
+	Kind Kind
+	Doc  string
+	get  func(T) value
+}
+
+// Schema is the field set expressions over T can use.
+type Schema[T any] map[string]Field[T]
+
+// StringField, NumberField, BoolField, ListField and TimeField describe a
+// field and how to read it.
+func StringField[T any](doc string, get func(T) string) Field[T] {
+	return Field[T]{Kind: KindString, Doc: doc, get: func(v T) value { return value{s: get(v)} }}
+}
+
+func NumberField[T any](doc string, get func(T) float64) Field[T] {
+	return Field[T]{Kind: KindNumber, Doc: doc, get: func(v T) value { return value{n: get(v)} }}
+}
+
+func BoolField[T any](doc string, get func(T) bool) Field[T] {
+	return Field[T]{Kind: KindBool, Doc: doc, get: func(v T) value { return value{b: get(v)} }}
+}
+
+func ListField[T any](doc string, get func(T) []string) Field[T] {
+	return Field[T]{Kind: KindList, Doc: doc, get: func(v T) value { return value{l: get(v)} }}
+}
+
+func TimeField[T any](doc string, get func(T) time.Time) Field[T] {
+	return Field[T]{Kind: KindTime, Doc: doc, get: func(v T) value { return value{t: get(v)} }}
+}
+
+// timeSuffixes are the readable parts of a time field.
+var timeSuffixes = map[string]struct {
+	kind Kind
+	get  func(time.Time) value
+}{
+	"date":    {KindString, func(t time.Time) value { return value{s: t.Format(models.DateFormat)} }},
+	"time":    {KindString, func(t time.Time) value { return value{s: t.Format("15:04")} }},
+	"weekday": {KindString, func(t time.Time) value { return value{s: t.Format("Mon")} }},
+	"hour":    {KindNumber, func(t time.Time) value { return value{n: float64(t.Hour())} }},
+	"minute":  {KindNumber, func(t time.Time) value { return value{n: float64(t.Minute())} }},
+}
+
+// eventFields returns the fields every models.Event has.
+func eventFields[T models.Event]() Schema[T] {
+	return Schema[T]{
+		"id":          StringField("the event ID", func(e T) string { return e.ID() }),
+		"name":        StringField("the event's title", func(e T) string { return e.Title() }),
+		"club":        StringField("the club's name", func(e T) string { return e.EventTenant().TenantName }),
+		"club_id":     StringField("the club's tenant ID", func(e T) string { return e.EventTenant().TenantID }),
+		"start":       TimeField("start, in the club's time zone", func(e T) time.Time { return e.Start() }),
+		"end":         TimeField("end, in the club's time zone", func(e T) time.Time { return e.End() }),
+		"capacity":    NumberField("total places", func(e T) float64 { return float64(e.Capacity()) }),
+		"free_places": NumberField("places still free", func(e T) float64 { return float64(e.FreePlaces()) }),
+		"price":       NumberField("price per player in currency units, e.g. 12.5", func(e T) float64 { return price(e.EventPrice()) }),
+		"players":     ListField("names of the players signed up", func(e T) []string { return playerNames(e.Participants()) }),
+	}
+}
+
+// with returns s plus extra, for model schemas built on eventFields.
+func (s Schema[T]) with(extra Schema[T]) Schema[T] {
+	for name, f := range extra {
+		s[name] = f
+	}
+	return s
+}
+
+// Tournaments is the field set of tournament filters.
+var Tournaments = eventFields[models.Tournament]().with(Schema[models.Tournament]{
+	"status":     StringField("e.g. PENDING", func(t models.Tournament) string { return string(t.Status) }),
+	"visibility": StringField("e.g. PUBLIC", func(t models.Tournament) string { return string(t.Visibility) }),
+})
+
+// Classes is the field set of class filters. name is the course name, or
+// the court name for sessions without a course.
+var Classes = eventFields[models.Class]().with(Schema[models.Class]{
+	"type":          StringField("e.g. COURSE", func(c models.Class) string { return string(c.Type) }),
+	"status":        StringField("e.g. PENDING", func(c models.Class) string { return string(c.Status) }),
+	"coaches":       ListField("coach names", func(c models.Class) []string { return coachNames(c.Coaches) }),
+	"registrations": NumberField("players registered", func(c models.Class) float64 { return float64(len(c.RegistrationInfo.Registrations)) }),
+	"cancelled":     BoolField("whether the class is cancelled", func(c models.Class) bool { return c.IsCanceled }),
+})
+
+// Matches is the field set of match filters. name is the match location.
+var Matches = eventFields[models.Match]().with(Schema[models.Match]{
+	"gender":     StringField("e.g. MIXED", func(m models.Match) string { return string(m.Gender) }),
+	"visibility": StringField("e.g. VISIBLE", func(m models.Match) string { return string(m.Visibility) }),
+	"min_level":  NumberField("lowest level allowed", func(m models.Match) float64 { return m.MinLevel }),
+	"max_level":  NumberField("highest level allowed; 0 means no limit", func(m models.Match) float64 { return m.MaxLevel }),
+	"avg_level":  NumberField("mean level of the players signed up", func(m models.Match) float64 { return m.AverageLevel() }),
+})
+
+// Lessons is the field set of lesson filters.
+var Lessons = eventFields[models.Lesson]().with(Schema[models.Lesson]{
+	"status":     StringField("e.g. REGISTRATION_OPEN", func(l models.Lesson) string { return string(l.TournamentStatus) }),
+	"visibility": StringField("e.g. PUBLIC", func(l models.Lesson) string { return string(l.TournamentVisibility) }),
+	"gender":     StringField("e.g. MIXED", func(l models.Lesson) string { return string(l.Gender) }),
+	"level":      StringField("the level description", func(l models.Lesson) string { return l.LevelDescription }),
+	"tags":       ListField("the lesson's tags", func(l models.Lesson) []string { return l.Tags }),
+	"coaches":    ListField("coach names", func(l models.Lesson) []string { return coachNames(l.Coaches) }),
+	"closes":     TimeField("when registration closes, in the club's time zone", func(l models.Lesson) time.Time { return l.RegistrationCloses() }),
+})
+
+// price returns m in currency units, or NaN for a price the API sent in a
+// form models.ParseMoney can't read, so no comparison with it holds.
+func price(m models.Money) float64 {
+	if !m.Known() {
+		return math.NaN()
+	}
+	return float64(m.Amount) / 100
+}
+
+func playerNames(players []models.Player) []string {
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func coachNames(coaches []models.Coach) []string {
+	names := make([]string, len(coaches))
+	for i, c := range coaches {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// names returns the schema's field names, sorted, for error messages.
+func (s Schema[T]) names() string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
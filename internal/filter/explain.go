@@ -0,0 +1,176 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/internal/config"
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// Verdict records why a filter kept or dropped one item.
+type Verdict struct {
+	ID   string
+	Name string
+	Kept bool
+	// Rules are the rules evaluated, in order. Evaluation stops at the
+	// first failure, which is then FailedRule, with Reason saying why.
+	Rules      []string
+	FailedRule string
+	Reason     string
+}
+
+// rule is one named check of a filter, named after the option it checks.
+// check returns "" if v passes, else why not.
+type rule[T any] struct {
+	name  string
+	check func(T) string
+}
+
+// ruleSet is a filter's checks in the order they run. Apply* functions
+// keep what passes every rule; Explain* functions report on each item.
+type ruleSet[T any] []rule[T]
+
+// add appends a rule when the option it checks is in use, so verdicts only
+// mention options the filter sets.
+func (rs *ruleSet[T]) add(when bool, name string, check func(T) string) {
+	if when {
+		*rs = append(*rs, rule[T]{name: name, check: check})
+	}
+}
+
+func (rs ruleSet[T]) keep(v T) bool {
+	for _, r := range rs {
+		if r.check(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (rs ruleSet[T]) explain(v T, id, name string) Verdict {
+	verdict := Verdict{ID: id, Name: name, Kept: true}
+	for _, r := range rs {
+		verdict.Rules = append(verdict.Rules, r.name)
+		if reason := r.check(v); reason != "" {
+			verdict.Kept, verdict.FailedRule, verdict.Reason = false, r.name, reason
+			break
+		}
+	}
+	return verdict
+}
+
+func explainEvents[T models.Event](items []T, rs ruleSet[T]) []Verdict {
+	verdicts := make([]Verdict, len(items))
+	for i, item := range items {
+		verdicts[i] = rs.explain(item, item.ID(), item.Title())
+	}
+	return verdicts
+}
+
+// Explain returns a verdict for every tournament, in order, on the rules
+// Apply uses.
+func Explain(tournaments []models.Tournament, f config.TournamentFilter) []Verdict {
+	return explainEvents(tournaments, tournamentRules(f))
+}
+
+// ExplainClasses returns a verdict for every class, in order, on the rules
+// ApplyClasses uses.
+func ExplainClasses(classes []models.Class, f config.ClassFilter) []Verdict {
+	return explainEvents(classes, classRules(f))
+}
+
+// ExplainMatches returns a verdict for every match, in order, on the rules
+// ApplyMatches uses.
+func ExplainMatches(matches []models.Match, f config.MatchFilter) []Verdict {
+	return explainEvents(matches, matchRules(f))
+}
+
+// ExplainLessons returns a verdict for every lesson, in order, on the rules
+// ApplyLessons uses.
+func ExplainLessons(lessons []models.Lesson, f config.LessonFilter) []Verdict {
+	return explainEvents(lessons, lessonRules(f))
+}
+
+// ExplainCourts returns a verdict for every slot of every court, on the
+// rules ApplyCourts uses. The ID is the court's resource ID and the name
+// the slot's local start and length. Group searches are explained by
+// ExplainCourtGroups.
+func ExplainCourts(courts []models.CourtAvailability, f config.CourtFilter, loc *time.Location) []Verdict {
+	rules := courtSlotRules(f, loc, true)
+
+	var verdicts []Verdict
+	for _, c := range courts {
+		for _, s := range c.Slots {
+			start := c.SlotStart(s, loc)
+			name := fmt.Sprintf("%s, %d min", start.Format("Mon 02 Jan 15:04"), s.Duration)
+			verdicts = append(verdicts, rules.explain(courtSlot{ResourceID: c.ResourceID, Slot: s, Start: start}, c.ResourceID, name))
+		}
+	}
+	return verdicts
+}
+
+// ExplainCourtGroups returns a verdict for every slot of every court on the
+// rules ApplyCourtGroups uses. A slot that a returned group books, as a
+// run's first slot or chained after it, is kept. Any other slot is dropped
+// on the first of: a slot rule, time_windows (only a run's first slot has to
+// start in one), consecutive_minutes (no long enough run starts with it) or
+// courts_needed (too few courts free at its start).
+func ExplainCourtGroups(courts []models.CourtAvailability, f config.CourtFilter, loc *time.Location) []Verdict {
+	rules := courtSlotRules(f, loc, false)
+	needed := max(f.CourtsNeeded, 1)
+	runs := courtRuns(courts, f, loc)
+
+	booked := make(map[string]bool)
+	for _, g := range ApplyCourtGroups(courts, f, loc) {
+		for _, run := range g.Courts {
+			for _, s := range run.Slots {
+				booked[slotKey(run.ResourceID, run.Date, s)] = true
+			}
+		}
+	}
+
+	var verdicts []Verdict
+	for _, c := range courts {
+		for _, s := range c.Slots {
+			start := c.SlotStart(s, loc)
+			name := fmt.Sprintf("%s, %d min", start.Format("Mon 02 Jan 15:04"), s.Duration)
+			v := rules.explain(courtSlot{ResourceID: c.ResourceID, Slot: s, Start: start}, c.ResourceID, name)
+			if !v.Kept {
+				verdicts = append(verdicts, v)
+				continue
+			}
+
+			v.Rules = append(v.Rules, "time_windows")
+			if f.ConsecutiveMinutes > 0 {
+				v.Rules = append(v.Rules, "consecutive_minutes")
+			}
+			if needed > 1 {
+				v.Rules = append(v.Rules, "courts_needed")
+			}
+			if !booked[slotKey(c.ResourceID, c.StartDate, s)] {
+				v.Kept = false
+				v.FailedRule, v.Reason = groupFailure(f, runs[start], c.ResourceID, start, needed)
+			}
+			verdicts = append(verdicts, v)
+		}
+	}
+	return verdicts
+}
+
+// groupFailure says why a slot passing the slot rules starts no group:
+// startRuns are the runs starting at the same time on any court.
+func groupFailure(f config.CourtFilter, startRuns []CourtRun, resourceID string, start time.Time, needed int) (rule, reason string) {
+	if reason := outsideWindows(start, f.TimeWindows); reason != "" {
+		return "time_windows", reason + ", and no run chains it"
+	}
+	if !slices.ContainsFunc(startRuns, func(r CourtRun) bool { return r.ResourceID == resourceID }) {
+		return "consecutive_minutes", fmt.Sprintf("no run of %d min starts here", f.ConsecutiveMinutes)
+	}
+	return "courts_needed", fmt.Sprintf("%d of %d courts free at %s", len(startRuns), needed, start.Format("15:04"))
+}
+
+func slotKey(resourceID string, date models.Date, s models.Slot) string {
+	return resourceID + "|" + date.String() + "|" + s.StartTime.String()
+}
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,151 +12,446 @@ import (
 
 // ApplyClasses returns the subset of classes that match the given filter criteria.
 func ApplyClasses(classes []models.Class, f config.ClassFilter) []models.Class {
+	rules := classRules(f)
 	var result []models.Class
 	for _, c := range classes {
-		if matchClass(c, f) {
+		if rules.keep(c) {
 			result = append(result, c)
 		}
 	}
 	return result
 }
 
-func matchClass(c models.Class, f config.ClassFilter) bool {
+func classRules(f config.ClassFilter) ruleSet[models.Class] {
+	var rs ruleSet[models.Class]
+
 	// Filter by course visibility. Previously done server-side via the
 	// course_visibility param, which the API no longer accepts.
-	if f.CourseVisibility != "" && c.CourseSummary != nil {
-		if !strings.EqualFold(c.CourseSummary.Visibility, f.CourseVisibility) {
-			return false
+	rs.add(f.CourseVisibility != "", "course_visibility", func(c models.Class) string {
+		if c.CourseSummary != nil && !strings.EqualFold(string(c.CourseSummary.Visibility), string(f.CourseVisibility)) {
+			return fmt.Sprintf("course is %s", c.CourseSummary.Visibility)
 		}
-	}
+		return ""
+	})
 
 	// Filter out full classes. Previously done server-side via the
 	// show_only_available param, which the API no longer accepts.
-	if f.ShowOnlyAvailable && !hasAvailablePlaces(c) {
-		return false
-	}
+	rs.add(f.ShowOnlyAvailable, "show_only_available", func(c models.Class) string {
+		if !hasAvailablePlaces(c) {
+			return "no free places"
+		}
+		return ""
+	})
 
 	// Filter by coach name
-	if len(f.CoachNames) > 0 && !hasAnyCoach(c, f.CoachNames) {
-		return false
-	}
+	rs.add(len(f.CoachNames) > 0, "coach_names", func(c models.Class) string {
+		if !hasAnyCoach(c, f.CoachNames, f.CoachNamesMode) {
+			return fmt.Sprintf("no coach matches %s", quoteList(f.CoachNames))
+		}
+		return ""
+	})
 
 	// Filter out if player is already registered
-	if f.PlayerName != "" && isRegistered(c, f.PlayerName) {
-		return false
-	}
+	rs.add(f.PlayerName != "", "player_name", func(c models.Class) string {
+		if isRegistered(c, f.PlayerName, f.PlayerNameMode) {
+			return fmt.Sprintf("%s already registered", f.PlayerName)
+		}
+		return ""
+	})
+
+	rs.add(f.SkipJoined, "skip_joined", func(c models.Class) string {
+		return joinedBy(c.Participants(), f.UserID)
+	})
 
 	// Filter by course name (whitelist)
-	if len(f.CourseNames) > 0 && c.CourseSummary != nil {
-		if !isInCourseNames(c.CourseSummary.Name, f.CourseNames) {
-			return false
+	rs.add(len(f.CourseNames) > 0, "course_names", func(c models.Class) string {
+		if c.CourseSummary != nil && !isInCourseNames(c.CourseSummary.Name, f.CourseNames, f.CourseNamesMode) {
+			return fmt.Sprintf("course %q matches none of %s", c.CourseSummary.Name, quoteList(f.CourseNames))
 		}
-	}
+		return ""
+	})
 
 	// Filter by blacklist
-	if c.CourseSummary != nil && isBlacklisted(c.CourseSummary.Name, f.Blacklist) {
-		return false
-	}
+	rs.add(len(f.Blacklist) > 0, "blacklist", func(c models.Class) string {
+		if c.CourseSummary != nil && isBlacklisted(c.CourseSummary.Name, f.Blacklist, f.BlacklistMode) {
+			return fmt.Sprintf("course %q is blacklisted", c.CourseSummary.Name)
+		}
+		return ""
+	})
+
+	rs.add(f.Origin != nil && f.MaxDistanceKm > 0, "max_distance_km", func(c models.Class) string {
+		return tooFar(f.Proximity, c.Position())
+	})
 
-	return true
+	addClassSchedule(&rs, f)
+
+	rs.add(f.WhereExpr != nil, "where", func(c models.Class) string {
+		if !f.WhereExpr.Match(c) {
+			return "where is false"
+		}
+		return ""
+	})
+	return rs
+}
+
+// addClassSchedule checks the class's start, in the club's time zone,
+// against the filter's weekdays, time windows, date range and lead time.
+func addClassSchedule(rs *ruleSet[models.Class], f config.ClassFilter) {
+	rs.add(len(f.Weekdays) > 0, "weekdays", func(c models.Class) string {
+		if day := c.Start().Weekday(); !f.IncludesWeekday(day) {
+			return fmt.Sprintf("starts on %s", day)
+		}
+		return ""
+	})
+	rs.add(len(f.TimeWindows) > 0, "time_windows", func(c models.Class) string {
+		return outsideWindows(c.Start(), f.TimeWindows)
+	})
+	rs.add(f.MinLeadTime > 0, "min_lead_time", func(c models.Class) string {
+		if start := c.Start(); start.Before(now().Add(f.MinLeadTime)) {
+			return fmt.Sprintf("starts in %s", start.Sub(now()).Round(time.Minute))
+		}
+		return ""
+	})
+	rs.add(f.From != "" || f.Until != "", "from/until", func(c models.Class) string {
+		start := c.Start()
+		from, until := f.DateRange(now().In(start.Location()))
+		date := start.Format(models.DateFormat)
+		if from != "" && date < from || until != "" && date > until {
+			return fmt.Sprintf("starts on %s, outside %s..%s", date, from, until)
+		}
+		return ""
+	})
 }
 
-// hasAvailablePlaces reports whether the class has at least one free place,
-// computed as MaxPlayers minus current registrations. Classes without a course
-// summary are treated as unavailable (we can't determine capacity).
+// hasAvailablePlaces reports whether the class has at least one free place.
+// Classes without a course summary are treated as unavailable (we can't
+// determine capacity, so FreePlaces is 0).
 func hasAvailablePlaces(c models.Class) bool {
-	if c.CourseSummary == nil {
-		return false
-	}
-	return c.CourseSummary.MaxPlayers-len(c.RegistrationInfo.Registrations) > 0
+	return c.FreePlaces() > 0
 }
 
-func hasAnyCoach(c models.Class, names []string) bool {
-	for _, name := range names {
-		lower := strings.ToLower(name)
+// Name lists (blacklist, coach_names, course_names) default to substring
+// matching, as they always have. Player names default to whole words, so a
+// full name matches exactly and a first name still finds its player, the
+// same way for every kind of event.
+const (
+	defaultListMode   = config.MatchLiteral
+	defaultPlayerMode = config.MatchWord
+)
 
-		for _, coach := range c.Coaches {
-			if strings.Contains(strings.ToLower(coach.Name), lower) {
-				return true
-			}
-		}
+func hasAnyCoach(c models.Class, names []string, mode config.MatchMode) bool {
+	var coaches []string
+	for _, coach := range c.Coaches {
+		coaches = append(coaches, coach.Name)
 	}
-	return false
+	return newNameMatcher(mode.Or(defaultListMode), names...).matchesAny(coaches)
 }
 
-func isRegistered(c models.Class, name string) bool {
-	lower := strings.ToLower(name)
+func isRegistered(c models.Class, name string, mode config.MatchMode) bool {
+	var players []string
 	for _, reg := range c.RegistrationInfo.Registrations {
-		if strings.Contains(strings.ToLower(reg.Player.Name), lower) {
-			return true
-		}
+		players = append(players, reg.Player.Name)
 	}
-	return false
+	return newNameMatcher(mode.Or(defaultPlayerMode), name).matchesAny(players)
 }
 
-func isInCourseNames(courseName string, courseNames []string) bool {
-	lower := strings.ToLower(courseName)
-	for _, cn := range courseNames {
-		if strings.Contains(lower, strings.ToLower(cn)) {
-			return true
+func isInCourseNames(courseName string, courseNames []string, mode config.MatchMode) bool {
+	return newNameMatcher(mode.Or(defaultListMode), courseNames...).matches(courseName)
+}
+
+// ApplyCoaches returns the coach schedules whose coach name matches any of
+// the filter's coach names. An empty CoachNames keeps every schedule.
+func ApplyCoaches(schedules []models.CoachSchedule, f config.CoachFilter) []models.CoachSchedule {
+	if len(f.CoachNames) == 0 {
+		return schedules
+	}
+
+	m := newNameMatcher(f.CoachNamesMode.Or(defaultListMode), f.CoachNames...)
+	var result []models.CoachSchedule
+	for _, s := range schedules {
+		if m.matches(s.Coach.Name) {
+			result = append(result, s)
 		}
 	}
-	return false
+	return result
 }
 
 // Apply returns the subset of tournaments that match the given filter criteria.
 func Apply(tournaments []models.Tournament, f config.TournamentFilter) []models.Tournament {
+	rules := tournamentRules(f)
 	var result []models.Tournament
 	for _, t := range tournaments {
-		if match(t, f) {
+		if rules.keep(t) {
 			result = append(result, t)
 		}
 	}
 	return result
 }
 
-func match(t models.Tournament, f config.TournamentFilter) bool {
-	if f.MinAvailablePlaces > 0 && t.AvailablePlaces < f.MinAvailablePlaces {
-		return false
+func tournamentRules(f config.TournamentFilter) ruleSet[models.Tournament] {
+	var rs ruleSet[models.Tournament]
+	rs.add(f.MinAvailablePlaces > 0, "min_available_places", func(t models.Tournament) string {
+		if t.AvailablePlaces < f.MinAvailablePlaces {
+			return fmt.Sprintf("%d places left, want %d", t.AvailablePlaces, f.MinAvailablePlaces)
+		}
+		return ""
+	})
+	rs.add(len(f.Blacklist) > 0, "blacklist", func(t models.Tournament) string {
+		if isBlacklisted(t.Name, f.Blacklist, f.BlacklistMode) {
+			return fmt.Sprintf("%q is blacklisted", t.Name)
+		}
+		return ""
+	})
+	rs.add(f.PlayerName != "", "player_name", func(t models.Tournament) string {
+		if hasPlayer(t, f.PlayerName, f.PlayerNameMode) {
+			return fmt.Sprintf("%s already registered", f.PlayerName)
+		}
+		return ""
+	})
+	rs.add(f.SkipJoined, "skip_joined", func(t models.Tournament) string {
+		return joinedBy(t.Participants(), f.UserID)
+	})
+	rs.add(f.Origin != nil && f.MaxDistanceKm > 0, "max_distance_km", func(t models.Tournament) string {
+		return tooFar(f.Proximity, t.Position())
+	})
+	rs.add(f.WhereExpr != nil, "where", func(t models.Tournament) string {
+		if !f.WhereExpr.Match(t) {
+			return "where is false"
+		}
+		return ""
+	})
+	return rs
+}
+
+func hasPlayer(t models.Tournament, name string, mode config.MatchMode) bool {
+	var names []string
+	for _, team := range t.Teams {
+		for _, p := range team.Players {
+			names = append(names, p.Name)
+		}
 	}
+	return newNameMatcher(mode.Or(defaultPlayerMode), name).matchesAny(names)
+}
 
-	if isBlacklisted(t.Name, f.Blacklist) {
-		return false
+// ApplyMatches returns the matches we could join: registration open,
+// enough free positions (at least one), our level within the match's
+// range, the requested gender, a start (in the club's time zone) inside a
+// time window, within MaxDistanceKm of Near, and not already joined by
+// PlayerName or, with SkipJoined, the configured user.
+func ApplyMatches(matches []models.Match, f config.MatchFilter) []models.Match {
+	rules := matchRules(f)
+	var result []models.Match
+	for _, m := range matches {
+		if rules.keep(m) {
+			result = append(result, m)
+		}
 	}
+	return result
+}
 
-	if f.PlayerName != "" && hasPlayer(t, f.PlayerName) {
-		return false
+func matchRules(f config.MatchFilter) ruleSet[models.Match] {
+	var rs ruleSet[models.Match]
+	rs.add(true, "registration_status", func(m models.Match) string {
+		if m.RegistrationStatus == models.RegistrationStatusClosed {
+			return "registration closed"
+		}
+		return ""
+	})
+	rs.add(true, "min_free_positions", func(m models.Match) string {
+		if want := max(f.MinFreePositions, 1); m.FreePositions() < want {
+			return fmt.Sprintf("%d free positions, want %d", m.FreePositions(), want)
+		}
+		return ""
+	})
+	rs.add(f.Level > 0, "level", func(m models.Match) string {
+		if !m.FitsLevel(f.Level) {
+			return fmt.Sprintf("level %.2f outside %s", f.Level, levelRange(m))
+		}
+		return ""
+	})
+	rs.add(f.Gender != "", "gender", func(m models.Match) string {
+		if !strings.EqualFold(string(m.Gender), string(f.Gender)) {
+			return fmt.Sprintf("gender is %s", m.Gender)
+		}
+		return ""
+	})
+	rs.add(len(f.TimeWindows) > 0, "time_windows", func(m models.Match) string {
+		return outsideWindows(m.Start(), f.TimeWindows)
+	})
+	rs.add(f.PlayerName != "", "player_name", func(m models.Match) string {
+		if isInMatch(m, f.PlayerName, f.PlayerNameMode) {
+			return fmt.Sprintf("%s already joined", f.PlayerName)
+		}
+		return ""
+	})
+	rs.add(f.SkipJoined, "skip_joined", func(m models.Match) string {
+		return joinedBy(m.Participants(), f.UserID)
+	})
+	rs.add(f.Origin != nil && f.MaxDistanceKm > 0, "max_distance_km", func(m models.Match) string {
+		return tooFar(f.Proximity, m.Position())
+	})
+	rs.add(f.WhereExpr != nil, "where", func(m models.Match) string {
+		if !f.WhereExpr.Match(m) {
+			return "where is false"
+		}
+		return ""
+	})
+	return rs
+}
+
+// levelRange formats a match's level range; a zero MaxLevel is open-ended.
+func levelRange(m models.Match) string {
+	if m.MaxLevel == 0 {
+		return fmt.Sprintf("%.2f+", m.MinLevel)
+	}
+	return fmt.Sprintf("%.2f-%.2f", m.MinLevel, m.MaxLevel)
+}
+
+func isInMatch(m models.Match, name string, mode config.MatchMode) bool {
+	var names []string
+	for _, p := range m.Participants() {
+		names = append(names, p.Name)
 	}
+	return newNameMatcher(mode.Or(defaultPlayerMode), name).matchesAny(names)
+}
 
-	return true
+// ApplyLessons returns the lessons that match the filter: not cancelled,
+// with enough available places, the requested gender, level description,
+// tags and price, not blacklisted, within MaxDistanceKm of Near, and not
+// already joined by PlayerName or, with SkipJoined, the configured user.
+// Visibility and status are filtered server-side.
+func ApplyLessons(lessons []models.Lesson, f config.LessonFilter) []models.Lesson {
+	rules := lessonRules(f)
+	var result []models.Lesson
+	for _, l := range lessons {
+		if rules.keep(l) {
+			result = append(result, l)
+		}
+	}
+	return result
 }
 
-func hasPlayer(t models.Tournament, name string) bool {
-	lower := strings.ToLower(name)
-	for _, team := range t.Teams {
-		for _, p := range team.Players {
-			if strings.ToLower(p.Name) == lower {
-				return true
-			}
+func lessonRules(f config.LessonFilter) ruleSet[models.Lesson] {
+	var rs ruleSet[models.Lesson]
+	rs.add(true, "cancelled", func(l models.Lesson) string {
+		if l.IsCancelled {
+			return "lesson is cancelled"
+		}
+		return ""
+	})
+	rs.add(f.MinAvailablePlaces > 0, "min_available_places", func(l models.Lesson) string {
+		if l.AvailablePlaces < f.MinAvailablePlaces {
+			return fmt.Sprintf("%d places left, want %d", l.AvailablePlaces, f.MinAvailablePlaces)
+		}
+		return ""
+	})
+	rs.add(f.Gender != "", "gender", func(l models.Lesson) string {
+		if !strings.EqualFold(string(l.Gender), string(f.Gender)) {
+			return fmt.Sprintf("gender is %s", l.Gender)
+		}
+		return ""
+	})
+	rs.add(f.LevelDescription != "", "level_description", func(l models.Lesson) string {
+		if !strings.Contains(strings.ToLower(l.LevelDescription), strings.ToLower(f.LevelDescription)) {
+			return fmt.Sprintf("level is %q", l.LevelDescription)
+		}
+		return ""
+	})
+	rs.add(len(f.Tags) > 0, "tags", func(l models.Lesson) string {
+		if !hasAnyTag(l.Tags, f.Tags) {
+			return fmt.Sprintf("tags %s match none of %s", quoteList(l.Tags), quoteList(f.Tags))
+		}
+		return ""
+	})
+	limit, hasLimit := f.MaxPriceMoney()
+	rs.add(hasLimit, "max_price", func(l models.Lesson) string {
+		if !priceWithin(l.Price, limit) {
+			return fmt.Sprintf("costs %s", l.Price)
+		}
+		return ""
+	})
+	rs.add(len(f.Blacklist) > 0, "blacklist", func(l models.Lesson) string {
+		if isBlacklisted(l.TournamentName, f.Blacklist, f.BlacklistMode) {
+			return fmt.Sprintf("%q is blacklisted", l.TournamentName)
+		}
+		return ""
+	})
+	rs.add(f.PlayerName != "", "player_name", func(l models.Lesson) string {
+		if lessonHasPlayer(l, f.PlayerName, f.PlayerNameMode) {
+			return fmt.Sprintf("%s already registered", f.PlayerName)
+		}
+		return ""
+	})
+	rs.add(f.SkipJoined, "skip_joined", func(l models.Lesson) string {
+		return joinedBy(l.Participants(), f.UserID)
+	})
+	rs.add(f.Origin != nil && f.MaxDistanceKm > 0, "max_distance_km", func(l models.Lesson) string {
+		return tooFar(f.Proximity, l.Position())
+	})
+	rs.add(f.WhereExpr != nil, "where", func(l models.Lesson) string {
+		if !f.WhereExpr.Match(l) {
+			return "where is false"
+		}
+		return ""
+	})
+	return rs
+}
+
+// hasAnyTag reports whether tags holds any wanted tag, ignoring case and
+// diacritics.
+func hasAnyTag(tags, wanted []string) bool {
+	for _, w := range wanted {
+		if slices.ContainsFunc(tags, func(t string) bool { return fold(t) == fold(w) }) {
+			return true
 		}
 	}
 	return false
 }
 
-// ApplyCourts filters slots to those with duration == 90 minutes that fall
-// within any of the configured time windows. Returns a copy of each
-// CourtAvailability containing only the matching slots; entries with no
-// matching slots are omitted.
-func ApplyCourts(courts []models.CourtAvailability, f config.CourtFilter) []models.CourtAvailability {
+func lessonHasPlayer(l models.Lesson, name string, mode config.MatchMode) bool {
+	var names []string
+	for _, p := range l.RegisteredPlayers {
+		names = append(names, p.FullName)
+	}
+	return newNameMatcher(mode.Or(defaultPlayerMode), name).matchesAny(names)
+}
+
+// RegistrationClosingSoon reports whether l's registration is still open
+// but closes within the given duration from now. Lessons without a closing
+// time never are.
+func RegistrationClosingSoon(l models.Lesson, within time.Duration) bool {
+	closes := l.RegistrationCloses()
+	if within <= 0 || closes.IsZero() {
+		return false
+	}
+	until := closes.Sub(now())
+	return until > 0 && until <= within
+}
+
+// now is the clock court and class filters measure lead time, lookahead
+// and relative dates against, and RegistrationClosingSoon measures closing
+// times against; tests replace it.
+var now = time.Now
+
+// ApplyCourts filters slots to those of one of the filter's durations
+// (90 minutes by default), within its price limit, that start within any of
+// the configured time windows, at least MinLeadTime from now, no more than
+// the lookahead days ahead, and on a wanted date. Windows, ignored days and
+// dates are read in loc, the club's local time, so a "17:00" window follows
+// the clock across DST changes. Returns a copy of each CourtAvailability
+// containing only the matching slots; entries with no matching slots are
+// omitted.
+func ApplyCourts(courts []models.CourtAvailability, f config.CourtFilter, loc *time.Location) []models.CourtAvailability {
+	rules := courtSlotRules(f, loc, true)
+
 	var result []models.CourtAvailability
 	for _, c := range courts {
-		if isIgnoredCourt(c.ResourceID, f.IgnoredCourtIDs) {
-			continue
-		}
-		if isIgnoredDay(c.StartDate, f.IgnoredDays) {
-			continue
+		var matched []models.Slot
+		for _, s := range c.Slots {
+			if rules.keep(courtSlot{ResourceID: c.ResourceID, Slot: s, Start: c.SlotStart(s, loc)}) {
+				matched = append(matched, s)
+			}
 		}
-		matched := matchSlots(c.Slots, f.TimeWindows)
 		if len(matched) > 0 {
 			result = append(result, models.CourtAvailability{
 				ResourceID: c.ResourceID,
@@ -176,15 +472,10 @@ func isIgnoredCourt(resourceID string, ignoredIDs []string) bool {
 	return false
 }
 
-func isIgnoredDay(startDate string, ignoredDays []string) bool {
-	if len(ignoredDays) == 0 {
-		return false
-	}
-	t, err := time.Parse("2006-01-02", startDate)
-	if err != nil {
-		return false
-	}
-	weekday := t.Weekday().String() // e.g. "Monday"
+// isIgnoredDay reports whether the local start falls on one of ignoredDays
+// ("Saturday", ...).
+func isIgnoredDay(start time.Time, ignoredDays []string) bool {
+	weekday := start.Weekday().String() // e.g. "Monday"
 	for _, d := range ignoredDays {
 		if strings.EqualFold(weekday, d) {
 			return true
@@ -193,29 +484,90 @@ func isIgnoredDay(startDate string, ignoredDays []string) bool {
 	return false
 }
 
-// matchSlots returns the slots that are 90 minutes long and start within any
-// of the given UTC time windows (HH:MM format).
-func matchSlots(slots []models.Slot, windows []config.TimeWindow) []models.Slot {
-	var result []models.Slot
-	for _, s := range slots {
-		if s.Duration != 90 {
-			continue
+// courtSlot is one slot of a court, with its start in the club's time zone.
+type courtSlot struct {
+	ResourceID string
+	Slot       models.Slot
+	Start      time.Time
+}
+
+// courtSlotRules returns a court filter's slot-level options (see
+// ApplyCourts), resolved against the clock once. Group searches only check
+// the windows for a run's first slot, so they leave them out.
+func courtSlotRules(f config.CourtFilter, loc *time.Location, withWindows bool) ruleSet[courtSlot] {
+	current := now().In(loc)
+	y, m, d := current.Date()
+	earliest := current.Add(f.MinLeadTime)
+	lastDate := time.Date(y, m, d+f.Lookahead(), 0, 0, 0, 0, loc).Format(models.DateFormat)
+	maxPrice, hasMaxPrice := f.MaxPriceMoney()
+
+	var rs ruleSet[courtSlot]
+	rs.add(len(f.IgnoredCourtIDs) > 0, "ignored_court_ids", func(cs courtSlot) string {
+		if isIgnoredCourt(cs.ResourceID, f.IgnoredCourtIDs) {
+			return "court is ignored"
 		}
-		if slotInAnyWindow(s.StartTime, windows) {
-			result = append(result, s)
+		return ""
+	})
+	rs.add(true, "slot_durations", func(cs courtSlot) string {
+		if !slices.Contains(f.SlotDurations(), cs.Slot.Duration) {
+			return fmt.Sprintf("lasts %d min, want %v", cs.Slot.Duration, f.SlotDurations())
 		}
-	}
-	return result
+		return ""
+	})
+	rs.add(hasMaxPrice, "max_price", func(cs courtSlot) string {
+		if !priceWithin(cs.Slot.Price, maxPrice) {
+			return fmt.Sprintf("costs %s", cs.Slot.Price)
+		}
+		return ""
+	})
+	rs.add(true, "min_lead_time", func(cs courtSlot) string {
+		if cs.Start.Before(earliest) {
+			return fmt.Sprintf("starts before %s", earliest.Format("Mon 02 Jan 15:04"))
+		}
+		return ""
+	})
+	rs.add(true, "lookahead_days", func(cs courtSlot) string {
+		if date := cs.Start.Format(models.DateFormat); date > lastDate {
+			return fmt.Sprintf("%s is after %s", date, lastDate)
+		}
+		return ""
+	})
+	rs.add(len(f.Dates) > 0 || len(f.ExcludeDates) > 0, "dates", func(cs courtSlot) string {
+		if date := cs.Start.Format(models.DateFormat); !f.IncludesDate(date) {
+			return fmt.Sprintf("%s is not a wanted date", date)
+		}
+		return ""
+	})
+	rs.add(len(f.IgnoredDays) > 0, "ignored_days", func(cs courtSlot) string {
+		if isIgnoredDay(cs.Start, f.IgnoredDays) {
+			return fmt.Sprintf("%s is ignored", cs.Start.Weekday())
+		}
+		return ""
+	})
+	rs.add(withWindows, "time_windows", func(cs courtSlot) string {
+		return outsideWindows(cs.Start, f.TimeWindows)
+	})
+	return rs
 }
 
-// slotInAnyWindow checks whether startTime ("HH:MM:SS") falls within any window.
-// A slot is considered inside a window when its start hour:minute is >= window
-// start and < window end.
-func slotInAnyWindow(startTime string, windows []config.TimeWindow) bool {
-	slotMins, err := parseHHMM(startTime)
-	if err != nil {
+// priceWithin reports whether price is at most max. A max without a
+// currency compares amounts only; a different currency never matches.
+func priceWithin(price, max models.Money) bool {
+	if !price.Known() {
 		return false
 	}
+	if max.Currency == "" {
+		return price.Amount <= max.Amount
+	}
+	cmp, err := price.Compare(max)
+	return err == nil && cmp <= 0
+}
+
+// slotInAnyWindow checks whether start falls within any window.
+// A slot is considered inside a window when its start hour:minute, on the
+// wall clock of start's location, is >= window start and < window end.
+func slotInAnyWindow(start time.Time, windows []config.TimeWindow) bool {
+	slotMins := start.Hour()*60 + start.Minute()
 	for _, w := range windows {
 		wStart, err := parseHHMM(w.Start)
 		if err != nil {
@@ -242,12 +594,32 @@ func parseHHMM(s string) (int, error) {
 	return h*60 + m, nil
 }
 
-func isBlacklisted(name string, blacklist []string) bool {
-	lower := strings.ToLower(name)
-	for _, b := range blacklist {
-		if strings.Contains(lower, strings.ToLower(b)) {
-			return true
-		}
+// outsideWindows returns why start is outside every window, or "" if it
+// is inside one.
+func outsideWindows(start time.Time, windows []config.TimeWindow) string {
+	if slotInAnyWindow(start, windows) {
+		return ""
 	}
-	return false
+	return fmt.Sprintf("starts at %s, outside every time window", start.Format("15:04"))
+}
+
+// tooFar returns how far a club at c is when p rules it out, or "".
+func tooFar(p config.Proximity, c models.Coordinate) string {
+	if p.Allows(c) {
+		return ""
+	}
+	return fmt.Sprintf("club is %.1f km from %s", p.Origin.DistanceTo(c)/1000, p.Near)
+}
+
+// quoteList formats names for a verdict's reason.
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func isBlacklisted(name string, blacklist []string, mode config.MatchMode) bool {
+	return newNameMatcher(mode.Or(defaultListMode), blacklist...).matches(name)
 }
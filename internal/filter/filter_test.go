@@ -1,10 +1,14 @@
 package filter
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/rafa-garcia/go-playtomic-api/internal/config"
+	"github.com/rafa-garcia/go-playtomic-api/internal/expr"
 	"github.com/rafa-garcia/go-playtomic-api/models"
+	"github.com/rafa-garcia/go-playtomic-api/models/fixtures"
 )
 
 func TestApply_Blacklist(t *testing.T) {
@@ -200,3 +204,684 @@ func TestApply_CombinedFilters(t *testing.T) {
 		t.Errorf("expected second result ID '4', got %q", result[1].TournamentID)
 	}
 }
+
+func TestApplyCoaches(t *testing.T) {
+	schedules := []models.CoachSchedule{
+		{Coach: models.Coach{UserID: "1", Name: "Ana Lopez"}},
+		{Coach: models.Coach{UserID: "2", Name: "Ben Smith"}},
+	}
+
+	result := ApplyCoaches(schedules, config.CoachFilter{TenantID: "t1", CoachNames: []string{"ana"}})
+	if len(result) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(result))
+	}
+	if result[0].Coach.UserID != "1" {
+		t.Errorf("expected coach '1', got %q", result[0].Coach.UserID)
+	}
+
+	all := ApplyCoaches(schedules, config.CoachFilter{TenantID: "t1"})
+	if len(all) != 2 {
+		t.Errorf("expected all schedules without coach_names, got %d", len(all))
+	}
+}
+
+func TestApply_Proximity(t *testing.T) {
+	home := models.Coordinate{Lat: 52.52, Lon: 13.405}
+	at := func(lat, lon float64) models.LessonTenant {
+		return models.LessonTenant{TenantAddress: models.Address{Coordinate: models.Coordinate{Lat: lat, Lon: lon}}}
+	}
+	tournaments := []models.Tournament{
+		{TournamentID: "near", Tenant: at(52.53, 13.41)},
+		{TournamentID: "far", Tenant: at(53.55, 9.99)},
+		{TournamentID: "unknown"},
+	}
+
+	f := config.TournamentFilter{
+		TenantID:  "t1",
+		Proximity: config.Proximity{Near: "home", MaxDistanceKm: 20, Origin: &home},
+	}
+
+	result := Apply(tournaments, f)
+	if len(result) != 2 || result[0].TournamentID != "near" || result[1].TournamentID != "unknown" {
+		t.Fatalf("expected [near unknown], got %v", result)
+	}
+
+	proximity := config.Proximity{Near: "home", MaxDistanceKm: 20, Origin: &home}
+	farMatch := fixtures.Match().WithID("far").WithPlayers(1).Build()
+	farMatch.Tenant.Address.Coordinate = models.Coordinate{Lat: 53.55, Lon: 9.99}
+	matches := []models.Match{fixtures.Match().WithID("near").WithPlayers(1).Build(), farMatch}
+	if got := ApplyMatches(matches, config.MatchFilter{Proximity: proximity}); len(got) != 1 || got[0].MatchID != "near" {
+		t.Errorf("expected only the near match, got %v", got)
+	}
+
+	lessons := []models.Lesson{
+		{TournamentID: "near", Tenant: at(52.53, 13.41)},
+		{TournamentID: "far", Tenant: at(53.55, 9.99)},
+	}
+	if got := ApplyLessons(lessons, config.LessonFilter{Proximity: proximity}); len(got) != 1 || got[0].TournamentID != "near" {
+		t.Errorf("expected only the near lesson, got %v", got)
+	}
+}
+
+func TestApplyCourts_LocalTimeAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	f := config.CourtFilter{
+		TenantID:    "t1",
+		TimeWindows: []config.TimeWindow{{Start: "17:00", End: "18:00"}},
+	}
+
+	// The same 16:00 UTC slot is 17:00 before a DST switch and 18:00 after
+	// it in spring; in autumn it's the other way round.
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2026-03-28", true},  // Sat, CET: 17:00 local
+		{"2026-03-29", false}, // Sun, CEST from 02:00: 18:00 local
+		{"2026-10-24", false}, // Sat, CEST: 18:00 local
+		{"2026-10-25", true},  // Sun, CET from 03:00: 17:00 local
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			setNow(t, models.MustParseDate(tt.date).AddDate(0, 0, -1))
+			courts := []models.CourtAvailability{
+				fixtures.Court().On(tt.date).WithSlot("16:00", 90, "36 EUR").Build(),
+			}
+			got := len(ApplyCourts(courts, f, berlin)) == 1
+			if got != tt.want {
+				t.Errorf("slot at 16:00 UTC on %s matched = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCourts_IgnoredDaysUseLocalDate(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	f := config.CourtFilter{
+		TenantID:    "t1",
+		TimeWindows: []config.TimeWindow{{Start: "00:00", End: "23:59"}},
+		IgnoredDays: []string{"Saturday"},
+	}
+
+	setNow(t, time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC))
+
+	// 23:30 UTC on Friday is already 00:30 on Saturday in Berlin.
+	courts := []models.CourtAvailability{
+		fixtures.Court().On("2026-03-27").WithSlot("21:00", 90, "36 EUR").WithSlot("23:30", 90, "36 EUR").Build(),
+	}
+	result := ApplyCourts(courts, f, berlin)
+	if len(result) != 1 || len(result[0].Slots) != 1 || result[0].Slots[0].StartTime.String() != "21:00:00" {
+		t.Fatalf("expected only the Friday-evening slot, got %+v", result)
+	}
+
+	// Read in UTC, both slots are on Friday.
+	if result := ApplyCourts(courts, f, time.UTC); len(result[0].Slots) != 2 {
+		t.Errorf("expected both slots in UTC, got %+v", result[0].Slots)
+	}
+}
+
+// setNow fixes the filter clock at t for the rest of the test.
+func setNow(t *testing.T, at time.Time) {
+	t.Helper()
+	orig := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = orig })
+}
+
+func TestApplyCourts_SlotOptions(t *testing.T) {
+	setNow(t, time.Date(2026, 4, 10, 15, 0, 0, 0, time.UTC))
+
+	courts := []models.CourtAvailability{
+		fixtures.Court().WithResource("today").On("2026-04-10").
+			WithSlot("15:15", 90, "36 EUR"). // too soon
+			WithSlot("16:00", 60, "24 EUR").
+			WithSlot("17:00", 90, "48 EUR"). // too expensive
+			WithSlot("18:00", 120, "40 EUR").
+			Build(),
+		fixtures.Court().WithResource("holiday").On("2026-04-11").WithSlot("16:00", 60, "24 EUR").Build(),
+		fixtures.Court().WithResource("later").On("2026-04-12").WithSlot("16:00", 60, "24 EUR").Build(),
+		fixtures.Court().WithResource("too-far").On("2026-04-14").WithSlot("16:00", 60, "24 EUR").Build(),
+	}
+
+	f := config.CourtFilter{
+		TenantID:      "t1",
+		TimeWindows:   []config.TimeWindow{{Start: "00:00", End: "23:59"}},
+		Durations:     []int{60, 120},
+		MaxPrice:      "40 EUR",
+		LookaheadDays: 3,
+		MinLeadTime:   30 * time.Minute,
+		ExcludeDates:  []string{"2026-04-11"},
+	}
+
+	result := ApplyCourts(courts, f, time.UTC)
+
+	var got []string
+	for _, c := range result {
+		for _, s := range c.Slots {
+			got = append(got, c.ResourceID+" "+s.StartTime.String())
+		}
+	}
+	want := []string{"today 16:00:00", "today 18:00:00", "later 16:00:00"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("slot %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	f.IgnoredCourtIDs = []string{"today"}
+	if result := ApplyCourts(courts, f, time.UTC); len(result) != 1 || result[0].ResourceID != "later" {
+		t.Errorf("expected the ignored court dropped, got %+v", result)
+	}
+	f.IgnoredCourtIDs = nil
+
+	f.Dates = []string{"2026-04-12"}
+	if result := ApplyCourts(courts, f, time.UTC); len(result) != 1 || result[0].ResourceID != "later" {
+		t.Errorf("expected only the 2026-04-12 court with dates set, got %+v", result)
+	}
+
+	// Without the options only 90-minute slots count, at any price, and a
+	// slot starting in 15 minutes is fine.
+	f = config.CourtFilter{TenantID: "t1", TimeWindows: []config.TimeWindow{{Start: "00:00", End: "23:59"}}}
+	if result := ApplyCourts(courts, f, time.UTC); len(result) != 1 || len(result[0].Slots) != 2 || result[0].Slots[0].Duration != 90 {
+		t.Errorf("expected the two 90-minute slots today, got %+v", result)
+	}
+}
+
+func TestApplyCourtGroups_CourtsNeeded(t *testing.T) {
+	setNow(t, time.Date(2026, 4, 10, 8, 0, 0, 0, time.UTC))
+
+	courts := []models.CourtAvailability{
+		fixtures.Court().WithResource("c1").On("2026-04-10").WithSlot("17:00", 90, "36 EUR").WithSlot("18:30", 90, "36 EUR").Build(),
+		fixtures.Court().WithResource("c2").On("2026-04-10").WithSlot("17:00", 90, "36 EUR").Build(),
+		fixtures.Court().WithResource("c3").On("2026-04-10").WithSlot("18:30", 90, "36 EUR").Build(),
+	}
+	f := config.CourtFilter{
+		TenantID:     "t1",
+		TimeWindows:  []config.TimeWindow{{Start: "17:00", End: "20:00"}},
+		CourtsNeeded: 2,
+	}
+
+	groups := ApplyCourtGroups(courts, f, time.UTC)
+	if len(groups) != 2 {
+		t.Fatalf("expected groups at 17:00 and 18:30, got %+v", groups)
+	}
+	if groups[0].Start.Hour() != 17 || len(groups[0].Courts) != 2 {
+		t.Errorf("expected c1 and c2 at 17:00, got %+v", groups[0])
+	}
+	if groups[1].Courts[0].ResourceID != "c1" || groups[1].Courts[1].ResourceID != "c3" {
+		t.Errorf("expected c1 and c3 at 18:30, got %+v", groups[1])
+	}
+
+	f.CourtsNeeded = 3
+	if groups := ApplyCourtGroups(courts, f, time.UTC); len(groups) != 0 {
+		t.Errorf("expected no time with 3 free courts, got %+v", groups)
+	}
+
+	// Ignored courts are dropped by the ignored_court_ids rule.
+	f.CourtsNeeded, f.IgnoredCourtIDs = 2, []string{"c2"}
+	if groups := ApplyCourtGroups(courts, f, time.UTC); len(groups) != 1 || groups[0].Start.Hour() != 18 {
+		t.Errorf("expected only the 18:30 group without c2, got %+v", groups)
+	}
+}
+
+func TestApplyCourtGroups_ConsecutiveMinutes(t *testing.T) {
+	setNow(t, time.Date(2026, 4, 10, 8, 0, 0, 0, time.UTC))
+
+	courts := []models.CourtAvailability{
+		fixtures.Court().WithResource("c1").On("2026-04-10").
+			WithSlot("17:00", 90, "36 EUR").
+			WithSlot("18:30", 90, "40 EUR").
+			WithSlot("20:30", 90, "40 EUR"). // gap after 20:00
+			Build(),
+	}
+	f := config.CourtFilter{
+		TenantID:           "t1",
+		TimeWindows:        []config.TimeWindow{{Start: "17:00", End: "19:00"}},
+		ConsecutiveMinutes: 180,
+	}
+
+	groups := ApplyCourtGroups(courts, f, time.UTC)
+	if len(groups) != 1 {
+		t.Fatalf("expected one 17:00 run, got %+v", groups)
+	}
+	run := groups[0].Courts[0]
+	if run.Minutes() != 180 || len(run.Slots) != 2 {
+		t.Errorf("expected two chained slots, got %+v", run)
+	}
+	if price, err := run.Price(); err != nil || price.String() != "76 EUR" {
+		t.Errorf("expected 76 EUR, got %v (%v)", price, err)
+	}
+
+	// The 18:30 slot starts a run too, but nothing follows 20:00.
+	f.TimeWindows = []config.TimeWindow{{Start: "18:00", End: "19:00"}}
+	if groups := ApplyCourtGroups(courts, f, time.UTC); len(groups) != 0 {
+		t.Errorf("expected no run from 18:30, got %+v", groups)
+	}
+}
+
+func TestApplyMatches(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	club := fixtures.Tenant().WithTimezone("Europe/Berlin").Build()
+	evening := time.Date(2026, 4, 10, 19, 0, 0, 0, berlin)
+
+	matches := []models.Match{
+		fixtures.Match().WithID("ok").AtTenant(club).Starting(evening).WithPlayers(2).Build(),
+		fixtures.Match().WithID("full").AtTenant(club).Starting(evening).WithPlayers(4).Build(),
+		fixtures.Match().WithID("closed").AtTenant(club).Starting(evening).WithPlayers(2).Closed().Build(),
+		fixtures.Match().WithID("level").AtTenant(club).Starting(evening).WithPlayers(2).Levels(4, 5).Build(),
+		fixtures.Match().WithID("gender").AtTenant(club).Starting(evening).WithPlayers(2).WithGender(models.GenderFemale).Build(),
+		// 16:00 in Berlin, before the window.
+		fixtures.Match().WithID("early").AtTenant(club).Starting(evening.Add(-3 * time.Hour)).WithPlayers(2).Build(),
+		fixtures.Match().WithID("three").AtTenant(club).Starting(evening).WithPlayers(1).Build(),
+		fixtures.Match().WithID("joined").AtTenant(club).Starting(evening).WithPlayers(1).
+			WithPlayer(1, fixtures.Player("Rafa", 3.0)).Build(),
+	}
+
+	f := config.MatchFilter{
+		TenantIDs:        []string{club.TenantID},
+		Level:            3.0,
+		Gender:           models.GenderMixed,
+		MinFreePositions: 2,
+		TimeWindows:      []config.TimeWindow{{Start: "18:00", End: "21:00"}},
+		PlayerName:       "rafa",
+	}
+
+	var got []string
+	for _, m := range ApplyMatches(matches, f) {
+		got = append(got, m.MatchID)
+	}
+	if len(got) != 2 || got[0] != "ok" || got[1] != "three" {
+		t.Errorf("expected [ok three], got %v", got)
+	}
+
+	// Without options, any open match with a free position is joinable.
+	if n := len(ApplyMatches(matches, config.MatchFilter{})); n != 6 {
+		t.Errorf("expected 6 joinable matches, got %d", n)
+	}
+}
+
+func TestApplyLessons(t *testing.T) {
+	lessons := []models.Lesson{
+		fixtures.Lesson().WithID("ok").WithLevel("Intermediate 3.0-4.0").WithTags("padel", "drills").Build(),
+		fixtures.Lesson().WithID("cancelled").WithLevel("Intermediate").WithTags("drills").Cancelled().Build(),
+		fixtures.Lesson().WithID("full").WithLevel("Intermediate").WithTags("drills").WithRegistrations(4).Build(),
+		fixtures.Lesson().WithID("beginner").WithLevel("Beginner").WithTags("drills").Build(),
+		fixtures.Lesson().WithID("untagged").WithLevel("Intermediate").Build(),
+		fixtures.Lesson().WithID("pricey").WithLevel("Intermediate").WithTags("drills").WithPrice("40 EUR").Build(),
+		fixtures.Lesson().WithID("ladies").WithLevel("Intermediate").WithTags("drills").WithName("Ladies Clinic").Build(),
+		fixtures.Lesson().WithID("female").WithLevel("Intermediate").WithTags("Drills").WithGender(models.GenderFemale).Build(),
+		fixtures.Lesson().WithID("joined").WithLevel("Intermediate").WithTags("drills").WithRegistrations(1).Build(),
+	}
+
+	f := config.LessonFilter{
+		TenantID:           "t1",
+		LevelDescription:   "intermediate",
+		Gender:             models.GenderMixed,
+		Tags:               []string{"DRILLS"},
+		MaxPrice:           "20 EUR",
+		MinAvailablePlaces: 1,
+		Blacklist:          []string{"ladies"},
+		PlayerName:         "player 1",
+	}
+
+	result := ApplyLessons(lessons, f)
+	if len(result) != 1 || result[0].TournamentID != "ok" {
+		t.Fatalf("expected [ok], got %v", result)
+	}
+}
+
+func TestRegistrationClosingSoon(t *testing.T) {
+	closes := time.Date(2026, 4, 9, 10, 0, 0, 0, time.UTC)
+	l := fixtures.Lesson().ClosingRegistration(closes).Build()
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		within time.Duration
+		want   bool
+	}{
+		{"well before", closes.Add(-48 * time.Hour), 24 * time.Hour, false},
+		{"within", closes.Add(-2 * time.Hour), 24 * time.Hour, true},
+		{"already closed", closes.Add(time.Minute), 24 * time.Hour, false},
+		{"disabled", closes.Add(-2 * time.Hour), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setNow(t, tt.now)
+			if got := RegistrationClosingSoon(l, tt.within); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	noClosing := fixtures.Lesson().ClosingRegistration(time.Time{}).Build()
+	if RegistrationClosingSoon(noClosing, 24*time.Hour) {
+		t.Error("expected a lesson without closing time never to close soon")
+	}
+}
+
+func TestApply_Where(t *testing.T) {
+	tournaments := []models.Tournament{
+		fixtures.Tournament().WithID("1").WithName("Spring Open").Build(),
+		fixtures.Tournament().WithID("2").WithName("Ladies Cup").Build(),
+		fixtures.Tournament().WithID("3").WithName("Summer Cup").WithAvailablePlaces(1).Build(),
+	}
+
+	where, err := expr.Compile(`free_places >= 2 && !(name ~ "(?i)ladies")`, expr.Tournaments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The expression is ANDed with the regular options.
+	f := config.TournamentFilter{TenantID: "t1", Blacklist: []string{"spring"}, WhereExpr: where}
+
+	if result := Apply(tournaments, f); len(result) != 0 {
+		t.Errorf("expected no tournaments, got %v", result)
+	}
+	f.Blacklist = nil
+	if result := Apply(tournaments, f); len(result) != 1 || result[0].TournamentID != "1" {
+		t.Errorf("expected [1], got %v", result)
+	}
+}
+
+func TestNameMatcher(t *testing.T) {
+	tests := []struct {
+		mode    config.MatchMode
+		pattern string
+		name    string
+		want    bool
+	}{
+		{config.MatchLiteral, "men", "Women's Americano", true},
+		{config.MatchWord, "men", "Women's Americano", false},
+		{config.MatchWord, "men", "Men's Americano", true},
+		{config.MatchWord, "ladies only", "LADIES ONLY Cup", true},
+		{config.MatchWord, "only ladies", "Ladies Only Cup", false},
+		{config.MatchLiteral, "inigo", "Iñigo Pérez", true},
+		{config.MatchWord, "Íñigo", "Inigo Perez", true},
+		{config.MatchWord, "inigo", "In\u0303igo Perez", true}, // decomposed ñ
+		{config.MatchLiteral, "strasse", "Padel Straße", true},
+		{config.MatchLiteral, "padel", "ＰＡＤＥＬ Club", true},
+		{config.MatchWord, "stefanescu", "Ștefănescu", true},
+		{config.MatchWord, "tuan", "Tuấn", true},
+		{config.MatchWord, "ngo", "Ngơ", true},
+		{config.MatchLiteral, "fit", "ﬁt Padel", true},
+		{config.MatchRegex, `^ion tiriac$`, "Ion Țiriac", true},
+		{config.MatchRegex, `^open\b`, "Open Padel", true},
+		{config.MatchRegex, `^open\b`, "Opening Night", false},
+		{config.MatchRegex, `^inigo$`, "Iñigo", true},
+		{config.MatchFuzzy, "Denis", "Deniz Yilmaz", true},
+		{config.MatchFuzzy, "Ana", "Anna B.", false},
+		{config.MatchFuzzy, "Christina", "Kristina", false},
+		{config.MatchFuzzy, "Christina Lopez", "Cristina Lopez", true},
+		{config.MatchWord, "", "Anything", false},
+	}
+	for _, tt := range tests {
+		if got := newNameMatcher(tt.mode, tt.pattern).matches(tt.name); got != tt.want {
+			t.Errorf("%s %q vs %q: expected %v, got %v", tt.mode, tt.pattern, tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPlayerNameSameForTournamentsAndClasses(t *testing.T) {
+	tournament := fixtures.Tournament().WithTeam("Taras S.", "John D.").Build()
+	class := fixtures.Class().WithRegistrations(1).Build()
+	class.RegistrationInfo.Registrations[0].Player.Name = "Taras S."
+
+	tests := []struct {
+		playerName string
+		mode       config.MatchMode
+		registered bool
+	}{
+		{"Taras S.", "", true},
+		{"taras", "", true},
+		{"tara", "", false},
+		{"tara", config.MatchLiteral, true},
+		{"Tarás S", config.MatchFuzzy, true},
+	}
+	for _, tt := range tests {
+		tf := config.TournamentFilter{TenantID: "t1", PlayerName: tt.playerName, PlayerNameMode: tt.mode}
+		cf := config.ClassFilter{TenantID: "t1", PlayerName: tt.playerName, PlayerNameMode: tt.mode}
+
+		if got := len(Apply([]models.Tournament{tournament}, tf)) == 0; got != tt.registered {
+			t.Errorf("tournament, %q (%s): expected registered=%v", tt.playerName, tt.mode, tt.registered)
+		}
+		if got := len(ApplyClasses([]models.Class{class}, cf)) == 0; got != tt.registered {
+			t.Errorf("class, %q (%s): expected registered=%v", tt.playerName, tt.mode, tt.registered)
+		}
+	}
+}
+
+func TestApplyClasses_Schedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	// Monday 2026-04-13, 12:00 in Berlin.
+	setNow(t, time.Date(2026, 4, 13, 12, 0, 0, 0, berlin))
+
+	at := func(id string, day, hour, minute int) models.Class {
+		return fixtures.Class().WithID(id).Starting(time.Date(2026, 4, day, hour, minute, 0, 0, berlin)).Build()
+	}
+	classes := []models.Class{
+		at("tue-evening", 14, 18, 30),
+		at("tue-morning", 14, 9, 0),
+		at("wed-evening", 15, 19, 0),
+		at("thu-evening", 16, 20, 0),
+		at("mon-soon", 13, 18, 30),     // Monday, and within the lead time
+		at("thu-too-late", 30, 19, 0),  // past until
+		at("tue-next-week", 21, 18, 0), // within the range
+	}
+
+	f := config.ClassFilter{
+		TenantID:    "t1",
+		Weekdays:    []string{"tue", "Thursday", "Mon"},
+		TimeWindows: []config.TimeWindow{{Start: "18:00", End: "22:00"}},
+		From:        "today",
+		Until:       "+2w",
+		MinLeadTime: 8 * time.Hour,
+	}
+
+	var got []string
+	for _, c := range ApplyClasses(classes, f) {
+		got = append(got, c.AcademyClassID)
+	}
+	want := []string{"tue-evening", "thu-evening", "tue-next-week"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// 18:30 Berlin is 16:30 UTC; windows follow the club's clock.
+	f = config.ClassFilter{TenantID: "t1", TimeWindows: []config.TimeWindow{{Start: "16:00", End: "17:00"}}}
+	if result := ApplyClasses(classes[:1], f); len(result) != 0 {
+		t.Errorf("expected the window to be read in local time, got %v", result)
+	}
+}
+
+func TestExplainMatches(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	club := fixtures.Tenant().WithTimezone("Europe/Berlin").Build()
+	evening := time.Date(2026, 4, 10, 19, 0, 0, 0, berlin)
+
+	matches := []models.Match{
+		fixtures.Match().WithID("ok").AtTenant(club).Starting(evening).WithPlayers(2).Build(),
+		fixtures.Match().WithID("closed").AtTenant(club).Starting(evening).WithPlayers(2).Closed().Build(),
+		fixtures.Match().WithID("level").AtTenant(club).Starting(evening).WithPlayers(2).Levels(4, 5).Build(),
+		fixtures.Match().WithID("early").AtTenant(club).Starting(evening.Add(-3 * time.Hour)).WithPlayers(2).Build(),
+	}
+	f := config.MatchFilter{
+		Level:       3.0,
+		TimeWindows: []config.TimeWindow{{Start: "18:00", End: "21:00"}},
+	}
+
+	verdicts := ExplainMatches(matches, f)
+	if len(verdicts) != len(matches) {
+		t.Fatalf("expected a verdict per match, got %d", len(verdicts))
+	}
+
+	tests := []struct {
+		kept   bool
+		rule   string
+		reason string
+	}{
+		{true, "", ""},
+		{false, "registration_status", "registration closed"},
+		{false, "level", "level 3.00 outside 4.00-5.00"},
+		{false, "time_windows", "starts at 16:00, outside every time window"},
+	}
+	for i, tt := range tests {
+		v := verdicts[i]
+		if v.ID != matches[i].MatchID || v.Kept != tt.kept || v.FailedRule != tt.rule || v.Reason != tt.reason {
+			t.Errorf("%s: expected kept=%v %s %q, got %+v", matches[i].MatchID, tt.kept, tt.rule, tt.reason, v)
+		}
+	}
+
+	// Only configured rules are evaluated, and evaluation stops at the
+	// first failure.
+	want := []string{"registration_status", "min_free_positions", "level", "time_windows"}
+	if !slices.Equal(verdicts[0].Rules, want) {
+		t.Errorf("expected rules %v, got %v", want, verdicts[0].Rules)
+	}
+	if !slices.Equal(verdicts[1].Rules, want[:1]) {
+		t.Errorf("expected evaluation to stop at registration_status, got %v", verdicts[1].Rules)
+	}
+
+	// Explain agrees with Apply.
+	kept := ApplyMatches(matches, f)
+	if len(kept) != 1 || kept[0].MatchID != "ok" {
+		t.Errorf("expected ApplyMatches to keep [ok], got %v", kept)
+	}
+}
+
+func TestExplainCourts(t *testing.T) {
+	setNow(t, time.Date(2026, 4, 10, 8, 0, 0, 0, time.UTC))
+
+	courts := []models.CourtAvailability{
+		fixtures.Court().WithResource("c1").On("2026-04-10").
+			WithSlot("18:00", 90, "36 EUR").
+			WithSlot("18:00", 60, "24 EUR").
+			WithSlot("10:00", 90, "36 EUR").Build(),
+		fixtures.Court().WithResource("c2").On("2026-04-10").WithSlot("18:00", 90, "36 EUR").Build(),
+	}
+	f := config.CourtFilter{
+		TimeWindows:     []config.TimeWindow{{Start: "17:00", End: "21:00"}},
+		IgnoredCourtIDs: []string{"c2"},
+	}
+
+	var got []string
+	for _, v := range ExplainCourts(courts, f, time.UTC) {
+		got = append(got, v.ID+" "+v.Name+" "+v.FailedRule)
+	}
+	want := []string{
+		"c1 Fri 10 Apr 18:00, 90 min ",
+		"c1 Fri 10 Apr 18:00, 60 min slot_durations",
+		"c1 Fri 10 Apr 10:00, 90 min time_windows",
+		"c2 Fri 10 Apr 18:00, 90 min ignored_court_ids",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestExplainCourtGroups(t *testing.T) {
+	setNow(t, time.Date(2026, 4, 10, 8, 0, 0, 0, time.UTC))
+
+	courts := []models.CourtAvailability{
+		fixtures.Court().WithResource("c1").On("2026-04-10").
+			WithSlot("17:00", 90, "36 EUR").
+			WithSlot("18:30", 90, "40 EUR"). // starts outside the window, chained from 17:00
+			WithSlot("10:00", 90, "36 EUR").Build(),
+		fixtures.Court().WithResource("c2").On("2026-04-10").
+			WithSlot("17:00", 90, "36 EUR").
+			WithSlot("18:30", 90, "40 EUR").Build(),
+		fixtures.Court().WithResource("c3").On("2026-04-10").WithSlot("16:00", 90, "36 EUR").Build(),
+		fixtures.Court().WithResource("c4").On("2026-04-10").WithSlot("16:00", 60, "24 EUR").Build(),
+	}
+	f := config.CourtFilter{
+		TimeWindows:        []config.TimeWindow{{Start: "16:00", End: "18:00"}},
+		Durations:          []int{60, 90},
+		CourtsNeeded:       2,
+		ConsecutiveMinutes: 180,
+	}
+
+	var got []string
+	for _, v := range ExplainCourtGroups(courts, f, time.UTC) {
+		got = append(got, v.ID+" "+v.Name+" "+v.FailedRule)
+	}
+	want := []string{
+		"c1 Fri 10 Apr 17:00, 90 min ",
+		"c1 Fri 10 Apr 18:30, 90 min ",
+		"c1 Fri 10 Apr 10:00, 90 min time_windows",
+		"c2 Fri 10 Apr 17:00, 90 min ",
+		"c2 Fri 10 Apr 18:30, 90 min ",
+		"c3 Fri 10 Apr 16:00, 90 min consecutive_minutes",
+		"c4 Fri 10 Apr 16:00, 60 min consecutive_minutes",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	// Runs long enough on too few courts fail courts_needed.
+	f.CourtsNeeded = 3
+	for _, v := range ExplainCourtGroups(courts[:2], f, time.UTC) {
+		if v.Kept {
+			t.Errorf("expected %s %s dropped with 3 courts needed", v.ID, v.Name)
+		}
+		if v.Name == "Fri 10 Apr 17:00, 90 min" && v.FailedRule != "courts_needed" {
+			t.Errorf("expected %s at 17:00 to fail courts_needed, got %q", v.ID, v.FailedRule)
+		}
+	}
+}
+
+func TestSkipJoinedByUserID(t *testing.T) {
+	me := fixtures.Player("Taras S.", 3.0)
+	// A namesake with a different user ID doesn't count as us.
+	namesake := fixtures.Player("Taras S.", 3.0)
+	namesake.UserID = "user-other"
+
+	matches := []models.Match{
+		fixtures.Match().WithID("joined").WithPlayers(1).WithPlayer(1, me).Build(),
+		fixtures.Match().WithID("namesake").WithPlayers(1).WithPlayer(1, namesake).Build(),
+	}
+	f := config.MatchFilter{Identity: config.Identity{SkipJoined: true, UserID: me.UserID}}
+
+	result := ApplyMatches(matches, f)
+	if len(result) != 1 || result[0].MatchID != "namesake" {
+		t.Fatalf("expected [namesake], got %v", result)
+	}
+	if v := ExplainMatches(matches, f)[0]; v.FailedRule != "skip_joined" || v.Reason != "user user-taras-s already registered" {
+		t.Errorf("unexpected verdict %+v", v)
+	}
+
+	tournament := fixtures.Tournament().WithTeam("Taras S.", "John D.").Build()
+	tf := config.TournamentFilter{Identity: config.Identity{SkipJoined: true, UserID: tournament.Teams[0].Players[0].UserID}}
+	if len(Apply([]models.Tournament{tournament}, tf)) != 0 {
+		t.Error("expected the tournament we're in to be skipped")
+	}
+}
+
+func TestFriends(t *testing.T) {
+	players := []models.Player{fixtures.Player("Ana", 3), fixtures.Player("Ben", 3), fixtures.Player("Cleo", 3)}
+	friends := Friends(players, []string{"user-cleo", "user-ana", "user-zoe"})
+	if len(friends) != 2 || friends[0].Name != "Ana" || friends[1].Name != "Cleo" {
+		t.Errorf("expected [Ana Cleo], got %v", friends)
+	}
+	if Friends(players, nil) != nil {
+		t.Error("expected no friends without friend IDs")
+	}
+}
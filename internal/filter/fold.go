@@ -0,0 +1,35 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// stripDiacritics returns the NFKD form of s with nonspacing marks dropped:
+// accented letters become their base letters, compatibility forms such as
+// fullwidth letters and ligatures their plain ones, so "Iñigo", its
+// decomposed form and "Ｉñｉｇｏ" all become "Inigo". Case is kept.
+func stripDiacritics(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(s))
+}
+
+// fold normalizes s for comparison: diacritics stripped and case folded
+// (which also turns "ß" into "ss").
+func fold(s string) string {
+	return cases.Fold().String(stripDiacritics(s))
+}
+
+// words splits folded text into its letter and digit runs.
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
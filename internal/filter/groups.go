@@ -0,0 +1,117 @@
+package filter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/internal/config"
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// CourtRun is one court booked for one or more back-to-back slots.
+type CourtRun struct {
+	ResourceID string
+	Date       models.Date // the availability date the slots belong to
+	Slots      []models.Slot
+}
+
+// Minutes returns the total length of the run.
+func (r CourtRun) Minutes() int {
+	total := 0
+	for _, s := range r.Slots {
+		total += s.Duration
+	}
+	return total
+}
+
+// Price returns the summed price of the run's slots.
+func (r CourtRun) Price() (models.Money, error) {
+	prices := make([]models.Money, len(r.Slots))
+	for i, s := range r.Slots {
+		prices[i] = s.Price
+	}
+	return models.SumMoney(prices...)
+}
+
+// CourtGroup is a combination that fits a group search: at least
+// CourtsNeeded courts, each free for at least ConsecutiveMinutes, all
+// starting at the same time.
+type CourtGroup struct {
+	Start  time.Time // local start shared by every run
+	Courts []CourtRun
+}
+
+// ApplyCourtGroups finds the combinations a group search asks for
+// (courts_needed and consecutive_minutes). Every slot must pass the same
+// options ApplyCourts checks, except that only a run's first slot has to
+// start within a time window; the slots chained after it may run past the
+// window's end. One group is returned per start time, listing every court
+// that can host the run, ordered by start.
+func ApplyCourtGroups(courts []models.CourtAvailability, f config.CourtFilter, loc *time.Location) []CourtGroup {
+	needed := max(f.CourtsNeeded, 1)
+
+	var groups []CourtGroup
+	for start, runs := range courtRuns(courts, f, loc) {
+		if len(runs) >= needed {
+			groups = append(groups, CourtGroup{Start: start, Courts: runs})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Start.Before(groups[j].Start) })
+	return groups
+}
+
+// courtRuns returns the runs of at least ConsecutiveMinutes that start
+// within a time window, by start, however many courts share the start.
+func courtRuns(courts []models.CourtAvailability, f config.CourtFilter, loc *time.Location) map[time.Time][]CourtRun {
+	rules := courtSlotRules(f, loc, false)
+
+	runsByStart := make(map[time.Time][]CourtRun)
+	for _, c := range courts {
+		var candidates []models.Slot
+		for _, s := range c.Slots {
+			if rules.keep(courtSlot{ResourceID: c.ResourceID, Slot: s, Start: c.SlotStart(s, loc)}) {
+				candidates = append(candidates, s)
+			}
+		}
+
+		seen := make(map[time.Time]bool) // one run per court and start
+		for _, s := range candidates {
+			start := c.SlotStart(s, loc)
+			if seen[start] || !slotInAnyWindow(start, f.TimeWindows) {
+				continue
+			}
+			run := chainSlots(candidates, []models.Slot{s}, f.ConsecutiveMinutes)
+			if run == nil {
+				continue
+			}
+			seen[start] = true
+			runsByStart[start] = append(runsByStart[start], CourtRun{ResourceID: c.ResourceID, Date: c.StartDate, Slots: run})
+		}
+	}
+	return runsByStart
+}
+
+// chainSlots extends run with slots starting exactly when the previous one
+// ends until it lasts at least minutes, returning nil if no chain gets
+// there. Each step moves the end time forward, so the search terminates.
+func chainSlots(slots, run []models.Slot, minutes int) []models.Slot {
+	total := 0
+	for _, s := range run {
+		total += s.Duration
+	}
+	if total >= minutes {
+		return run
+	}
+
+	last := run[len(run)-1]
+	end := last.StartTime.Minutes() + last.Duration
+	for _, s := range slots {
+		if s.StartTime.Minutes() != end {
+			continue
+		}
+		if chained := chainSlots(slots, append(run[:len(run):len(run)], s), minutes); chained != nil {
+			return chained
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// joinedBy returns why players rule an event out for skip_joined, or "" if
+// userID isn't among them. Players are compared by user ID, never by name.
+func joinedBy(players []models.Player, userID string) string {
+	if userID != "" && slices.ContainsFunc(players, func(p models.Player) bool { return p.UserID == userID }) {
+		return fmt.Sprintf("user %s already registered", userID)
+	}
+	return ""
+}
+
+// Friends returns the players whose user ID is in friendIDs, in the order
+// they appear in players.
+func Friends(players []models.Player, friendIDs []string) []models.Player {
+	var friends []models.Player
+	for _, p := range players {
+		if p.UserID != "" && slices.Contains(friendIDs, p.UserID) {
+			friends = append(friends, p)
+		}
+	}
+	return friends
+}
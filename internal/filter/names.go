@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rafa-garcia/go-playtomic-api/internal/config"
+)
+
+// nameMatcher matches names against a filter's name list in one
+// config.MatchMode. Every mode compares folded text (see fold), so case and
+// diacritics never matter.
+type nameMatcher struct {
+	mode     config.MatchMode
+	patterns []string
+}
+
+func newNameMatcher(mode config.MatchMode, patterns ...string) nameMatcher {
+	return nameMatcher{mode: mode, patterns: patterns}
+}
+
+// matches reports whether name matches any pattern. Empty patterns never
+// match.
+func (m nameMatcher) matches(name string) bool {
+	for _, p := range m.patterns {
+		if strings.TrimSpace(p) != "" && m.matchesOne(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether any of names matches.
+func (m nameMatcher) matchesAny(names []string) bool {
+	return slices.ContainsFunc(names, m.matches)
+}
+
+func (m nameMatcher) matchesOne(name, pattern string) bool {
+	switch m.mode {
+	case config.MatchWord:
+		return containsWords(words(fold(name)), words(fold(pattern)), 0)
+	case config.MatchFuzzy:
+		want := words(fold(pattern))
+		return containsWords(words(fold(name)), want, fuzzyTolerance(want))
+	case config.MatchRegex:
+		re := compileRegexp("(?i)" + pattern)
+		return re != nil && (re.MatchString(name) || re.MatchString(stripDiacritics(name)))
+	}
+	return strings.Contains(fold(name), fold(pattern))
+}
+
+// containsWords reports whether want appears as consecutive words in have,
+// within maxEdits total edits.
+func containsWords(have, want []string, maxEdits int) bool {
+	if len(want) == 0 {
+		return false
+	}
+	for i := 0; i+len(want) <= len(have); i++ {
+		edits := 0
+		for j, w := range want {
+			edits += editDistance(have[i+j], w)
+		}
+		if edits <= maxEdits {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyTolerance allows one edit per five letters of the pattern, so
+// "Denis" finds "Deniz" but "Ana" needs to be spelled right.
+func fuzzyTolerance(pattern []string) int {
+	letters := 0
+	for _, w := range pattern {
+		letters += len([]rune(w))
+	}
+	return letters / 5
+}
+
+// editDistance returns the Levenshtein distance between a and b in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// regexpCache holds compiled regex patterns, which config.Load has already
+// checked, so filters don't recompile them for every event.
+var regexpCache sync.Map // pattern -> *regexp.Regexp, nil if invalid
+
+func compileRegexp(pattern string) *regexp.Regexp {
+	if re, ok := regexpCache.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	regexpCache.Store(pattern, re)
+	return re
+}
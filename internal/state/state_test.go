@@ -0,0 +1,68 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+	"github.com/rafa-garcia/go-playtomic-api/models/fixtures"
+)
+
+func TestShouldNotify(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "state.json"))
+
+	if !s.ShouldNotify("t1", 2) {
+		t.Error("expected a notification for a new ID")
+	}
+	s.Update("t1", 2)
+	if s.ShouldNotify("t1", 2) || s.ShouldNotify("t1", 1) {
+		t.Error("expected no notification when places stay the same or drop")
+	}
+	if !s.ShouldNotify("t1", 3) {
+		t.Error("expected a notification when places increase")
+	}
+}
+
+// An overbooked class has more registrations than places. FreePlaces floors
+// it at 0, the same as a full class, so dropping back to exactly full
+// doesn't notify; the first place that is actually free does.
+func TestShouldNotifyOverbookedClass(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "state.json"))
+	class := func(registrations int) models.Class {
+		return fixtures.Class().WithID("c1").WithMaxPlayers(4).WithRegistrations(registrations).Build()
+	}
+
+	overbooked := class(5)
+	if got := overbooked.FreePlaces(); got != 0 {
+		t.Fatalf("expected an overbooked class to have 0 free places, got %d", got)
+	}
+	s.Update("c1", overbooked.FreePlaces())
+
+	full := class(4)
+	if s.ShouldNotify("c1", full.FreePlaces()) {
+		t.Error("expected no notification when an overbooked class is still full")
+	}
+	s.Update("c1", full.FreePlaces())
+
+	free := class(3)
+	if !s.ShouldNotify("c1", free.FreePlaces()) {
+		t.Error("expected a notification once a place is free")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s := New(path)
+	s.Update("t1", 1)
+	if err := s.Save(); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	loaded := New(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if loaded.ShouldNotify("t1", 1) || !loaded.ShouldNotify("t1", 2) {
+		t.Error("expected the saved entry to be restored")
+	}
+}
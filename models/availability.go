@@ -1,25 +1,35 @@
 package models
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 // Slot represents a single available time slot for a court.
 type Slot struct {
-	StartTime string `json:"start_time"` // "21:00:00" UTC
-	Duration  int    `json:"duration"`   // minutes
-	Price     string `json:"price"`      // "36 EUR"
+	StartTime TimeOfDay `json:"start_time"` // "21:00:00" UTC
+	Duration  int       `json:"duration"`   // minutes
+	Price     Money     `json:"price"`      // "36 EUR"
 }
 
 // CourtAvailability represents availability for a single court (resource) on a given date.
 type CourtAvailability struct {
 	ResourceID string `json:"resource_id"`
-	StartDate  string `json:"start_date"` // "2026-04-10"
+	StartDate  Date   `json:"start_date"` // "2026-04-10"
 	Slots      []Slot `json:"slots"`
 }
 
+// SlotStart returns the instant slot s starts, in loc. Availability
+// responses carry no tenant, so the caller supplies the zone (typically the
+// tenant's Address.Location()).
+func (c CourtAvailability) SlotStart(s Slot, loc *time.Location) time.Time {
+	return s.StartTime.On(c.StartDate, time.UTC).In(loc)
+}
+
 // SearchAvailabilityParams holds parameters for the /v1/availability endpoint.
 type SearchAvailabilityParams struct {
 	TenantID string
-	SportID  string
+	SportID  SportID
 	StartMin string // UTC datetime without timezone, e.g. "2026-04-09T22:00:00"
 	StartMax string // UTC datetime without timezone, e.g. "2026-04-10T21:59:59"
 }
@@ -27,7 +37,7 @@ type SearchAvailabilityParams struct {
 func (p *SearchAvailabilityParams) ToURLValues() url.Values {
 	values := url.Values{}
 	values.Set("tenant_id", p.TenantID)
-	values.Set("sport_id", p.SportID)
+	values.Set("sport_id", string(p.SportID))
 	values.Set("start_min", p.StartMin)
 	values.Set("start_max", p.StartMax)
 	return values
@@ -4,17 +4,18 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Class represents a class from the Playtomic API
 type Class struct {
-	Type             string           `json:"type"`
+	Type             ClassType        `json:"type"`
 	AcademyClassID   string           `json:"academy_class_id"`
-	SportID          string           `json:"sport_id"`
+	SportID          SportID          `json:"sport_id"`
 	Tenant           Tenant           `json:"tenant"`
 	Resource         Resource         `json:"resource"`
-	StartDate        string           `json:"start_date"`
-	EndDate          string           `json:"end_date"`
+	StartDate        Time             `json:"start_date"`
+	EndDate          Time             `json:"end_date"`
 	Coaches          []Coach          `json:"coaches"`
 	RegistrationInfo RegistrationInfo `json:"registration_info"`
 	CourseSummary    *CourseSummary   `json:"course_summary,omitempty"`
@@ -23,18 +24,28 @@ type Class struct {
 	IsCanceled       bool             `json:"is_canceled"`
 	PrivateNotes     *string          `json:"private_notes"`
 	PublicNotes      string           `json:"public_notes"`
-	Status           string           `json:"status"`
+	Status           ClassStatus      `json:"status"`
 	PaymentStatus    string           `json:"payment_status"`
 }
 
+// Start returns the class start time in the tenant's time zone.
+func (c Class) Start() time.Time {
+	return c.StartDate.In(c.Tenant.Address.Location())
+}
+
+// End returns the class end time in the tenant's time zone.
+func (c Class) End() time.Time {
+	return c.EndDate.In(c.Tenant.Address.Location())
+}
+
 // CourseSummary represents summary information about a course
 type CourseSummary struct {
-	CourseID   string `json:"course_id"`
-	Name       string `json:"name"`
-	Gender     string `json:"gender"`
-	Visibility string `json:"visibility"`
-	MinPlayers int    `json:"min_players"`
-	MaxPlayers int    `json:"max_players"`
+	CourseID   string     `json:"course_id"`
+	Name       string     `json:"name"`
+	Gender     Gender     `json:"gender"`
+	Visibility Visibility `json:"visibility"`
+	MinPlayers int        `json:"min_players"`
+	MaxPlayers int        `json:"max_players"`
 }
 
 // MaxClassesPageSize is the maximum page size accepted by the v1/classes endpoint.
@@ -44,8 +55,8 @@ const MaxClassesPageSize = 50
 // SearchClassesParams defines parameters for searching classes
 type SearchClassesParams struct {
 	Sort           string
-	Status         string
-	Type           string
+	Status         []ClassStatus
+	Type           []ClassType
 	TenantIDs      []string
 	IncludeSummary bool
 	Size           int
@@ -63,11 +74,11 @@ func (p *SearchClassesParams) ToURLValues() url.Values {
 		values.Set("sort", s)
 	}
 
-	if s := strings.TrimSpace(p.Status); s != "" {
+	if s := joinEnums(p.Status); s != "" {
 		values.Set("status", s)
 	}
 
-	if t := strings.TrimSpace(p.Type); t != "" {
+	if t := joinEnums(p.Type); t != "" {
 		values.Set("type", t)
 	}
 
@@ -23,8 +23,8 @@ func TestSearchClassesParamsToURLValues(t *testing.T) {
 			name: "Complete params",
 			params: SearchClassesParams{
 				Sort:           "start_date,ASC",
-				Status:         "PENDING,IN_PROGRESS",
-				Type:           "COURSE,PUBLIC",
+				Status:         []ClassStatus{ClassStatusPending, ClassStatusInProgress},
+				Type:           []ClassType{ClassTypeCourse, ClassTypePublic},
 				TenantIDs:      []string{"tenant-123", "tenant-456"},
 				IncludeSummary: true,
 				Size:           50,
@@ -70,8 +70,8 @@ func TestSearchClassesParamsToURLValues(t *testing.T) {
 			name: "Whitespace handling",
 			params: SearchClassesParams{
 				Sort:   "  start_date,ASC  ",
-				Status: "  PENDING,IN_PROGRESS  ",
-				Type:   "  COURSE,PUBLIC  ",
+				Status: []ClassStatus{"  PENDING", "IN_PROGRESS  "},
+				Type:   []ClassType{"  COURSE", "PUBLIC  "},
 			},
 			expected: url.Values{
 				"sort":   []string{"start_date,ASC"},
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"sort"
+	"time"
+)
+
 // Coach represents a coach
 type Coach struct {
 	UserID                 string  `json:"user_id"`
@@ -14,3 +19,105 @@ type Coach struct {
 	CommunicationsLanguage string  `json:"communications_language"`
 	IsPremium              bool    `json:"is_premium"`
 }
+
+// CoachSession is a single session taught by a coach, taken from either a
+// class or a lesson.
+type CoachSession struct {
+	ID         string
+	Kind       EventKind // EventKindClass or EventKindLesson
+	Name       string
+	Start      time.Time // in the tenant's time zone
+	End        time.Time // in the tenant's time zone
+	TenantID   string
+	TenantName string
+}
+
+// CoachSchedule is a coach together with the sessions they teach, ordered
+// by start date.
+type CoachSchedule struct {
+	Coach    Coach
+	Sessions []CoachSession
+}
+
+// CoachesFromClasses returns the distinct coaches teaching the given classes,
+// keyed on UserID, in order of first appearance.
+func CoachesFromClasses(classes []Class) []Coach {
+	seen := make(map[string]bool)
+	var coaches []Coach
+	for _, c := range classes {
+		for _, coach := range c.Coaches {
+			if coach.UserID == "" || seen[coach.UserID] {
+				continue
+			}
+			seen[coach.UserID] = true
+			coaches = append(coaches, coach)
+		}
+	}
+	return coaches
+}
+
+// BuildCoachSchedules groups classes and lessons by Coach.UserID into one
+// schedule per coach. Schedules are sorted by coach name and sessions by
+// start date. Coaches without a UserID are skipped, since they can't be told
+// apart reliably.
+func BuildCoachSchedules(classes []Class, lessons []Lesson) []CoachSchedule {
+	byID := make(map[string]*CoachSchedule)
+	var order []string
+
+	add := func(coach Coach, session CoachSession) {
+		if coach.UserID == "" {
+			return
+		}
+		s, ok := byID[coach.UserID]
+		if !ok {
+			s = &CoachSchedule{Coach: coach}
+			byID[coach.UserID] = s
+			order = append(order, coach.UserID)
+		}
+		s.Sessions = append(s.Sessions, session)
+	}
+
+	for _, c := range classes {
+		session := CoachSession{
+			ID:         c.ID(),
+			Kind:       c.Kind(),
+			Name:       c.Title(),
+			Start:      c.Start(),
+			End:        c.End(),
+			TenantID:   c.Tenant.TenantID,
+			TenantName: c.Tenant.TenantName,
+		}
+		for _, coach := range c.Coaches {
+			add(coach, session)
+		}
+	}
+
+	for _, l := range lessons {
+		session := CoachSession{
+			ID:         l.ID(),
+			Kind:       l.Kind(),
+			Name:       l.Title(),
+			Start:      l.Start(),
+			End:        l.End(),
+			TenantID:   l.Tenant.TenantID,
+			TenantName: l.Tenant.TenantName,
+		}
+		for _, coach := range l.Coaches {
+			add(coach, session)
+		}
+	}
+
+	schedules := make([]CoachSchedule, 0, len(order))
+	for _, id := range order {
+		s := byID[id]
+		sort.SliceStable(s.Sessions, func(i, j int) bool {
+			return s.Sessions[i].Start.Before(s.Sessions[j].Start)
+		})
+		schedules = append(schedules, *s)
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].Coach.Name < schedules[j].Coach.Name
+	})
+
+	return schedules
+}
@@ -0,0 +1,86 @@
+package models
+
+import "testing"
+
+func TestCoachesFromClasses(t *testing.T) {
+	ana := Coach{UserID: "coach-1", Name: "Ana"}
+	ben := Coach{UserID: "coach-2", Name: "Ben"}
+
+	classes := []Class{
+		{AcademyClassID: "class-1", Coaches: []Coach{ana}},
+		{AcademyClassID: "class-2", Coaches: []Coach{ben, ana}},
+		{AcademyClassID: "class-3", Coaches: []Coach{{Name: "No ID"}}},
+	}
+
+	coaches := CoachesFromClasses(classes)
+
+	if len(coaches) != 2 {
+		t.Fatalf("Expected 2 coaches, got %d", len(coaches))
+	}
+	if coaches[0].UserID != "coach-1" || coaches[1].UserID != "coach-2" {
+		t.Errorf("Expected coaches in order of first appearance, got %+v", coaches)
+	}
+}
+
+func TestBuildCoachSchedules(t *testing.T) {
+	ana := Coach{UserID: "coach-1", Name: "Ana"}
+	ben := Coach{UserID: "coach-2", Name: "Ben"}
+
+	classes := []Class{
+		{
+			AcademyClassID: "class-2",
+			StartDate:      MustParseTime("2026-05-02T18:00:00"),
+			Coaches:        []Coach{ana},
+			CourseSummary:  &CourseSummary{Name: "Intermediate"},
+			Tenant:         Tenant{TenantID: "tenant-1", TenantName: "Test Club"},
+		},
+		{
+			AcademyClassID: "class-1",
+			StartDate:      MustParseTime("2026-05-01T18:00:00"),
+			Coaches:        []Coach{ben, ana},
+			Resource:       Resource{Name: "Court 1"},
+		},
+	}
+	lessons := []Lesson{
+		{
+			TournamentID:   "lesson-1",
+			TournamentName: "Americano Clinic",
+			StartDate:      MustParseTime("2026-05-01T10:00:00"),
+			Coaches:        []Coach{ana},
+		},
+	}
+
+	schedules := BuildCoachSchedules(classes, lessons)
+
+	if len(schedules) != 2 {
+		t.Fatalf("Expected 2 schedules, got %d", len(schedules))
+	}
+
+	anaSchedule := schedules[0]
+	if anaSchedule.Coach.Name != "Ana" {
+		t.Fatalf("Expected schedules sorted by coach name, got %s first", anaSchedule.Coach.Name)
+	}
+	if len(anaSchedule.Sessions) != 3 {
+		t.Fatalf("Expected 3 sessions for Ana, got %d", len(anaSchedule.Sessions))
+	}
+
+	wantIDs := []string{"lesson-1", "class-1", "class-2"}
+	for i, id := range wantIDs {
+		if anaSchedule.Sessions[i].ID != id {
+			t.Errorf("Session %d: expected ID %s, got %s", i, id, anaSchedule.Sessions[i].ID)
+		}
+	}
+	if anaSchedule.Sessions[0].Kind != EventKindLesson {
+		t.Errorf("Expected first session kind %s, got %s", EventKindLesson, anaSchedule.Sessions[0].Kind)
+	}
+	if anaSchedule.Sessions[1].Name != "Court 1" {
+		t.Errorf("Expected class without course summary to fall back to resource name, got %s", anaSchedule.Sessions[1].Name)
+	}
+	if anaSchedule.Sessions[2].Name != "Intermediate" {
+		t.Errorf("Expected course name 'Intermediate', got %s", anaSchedule.Sessions[2].Name)
+	}
+
+	if len(schedules[1].Sessions) != 1 {
+		t.Errorf("Expected 1 session for Ben, got %d", len(schedules[1].Sessions))
+	}
+}
@@ -13,6 +13,16 @@ func LessonPlayerToPlayer(lp *LessonPlayer) Player {
 	}
 }
 
+// TournamentPlayerToPlayer converts a TournamentPlayer to a Player
+func TournamentPlayerToPlayer(tp *TournamentPlayer) Player {
+	return Player{
+		BasePlayer: BasePlayer{
+			UserID: tp.UserID,
+		},
+		Name: tp.Name,
+	}
+}
+
 // LessonTenantToTenant converts a LessonTenant to a Tenant
 func LessonTenantToTenant(lt *LessonTenant) Tenant {
 	return Tenant{
@@ -37,6 +37,23 @@ func TestLessonPlayerToPlayer(t *testing.T) {
 	}
 }
 
+func TestTournamentPlayerToPlayer(t *testing.T) {
+	tournamentPlayer := TournamentPlayer{
+		Name:   "Jane Doe",
+		UserID: "user-789",
+	}
+
+	player := TournamentPlayerToPlayer(&tournamentPlayer)
+
+	if player.UserID != tournamentPlayer.UserID {
+		t.Errorf("UserID not correctly mapped. Expected %s, got %s", tournamentPlayer.UserID, player.UserID)
+	}
+
+	if player.Name != tournamentPlayer.Name {
+		t.Errorf("Name not correctly mapped. Expected %s, got %s", tournamentPlayer.Name, player.Name)
+	}
+}
+
 func TestLessonTenantToTenant(t *testing.T) {
 	address := Address{
 		Street:                "123 Main St",
@@ -0,0 +1,82 @@
+package models
+
+// Course is an academy programme: the class sessions that share a
+// CourseSummary.CourseID. The API returns each session as an independent
+// Class; GroupCourses reassembles them.
+type Course struct {
+	CourseID   string
+	Name       string
+	Gender     Gender
+	Visibility Visibility
+	MinPlayers int
+	MaxPlayers int
+	Tenant     Tenant
+	Sessions   []Class // ordered as returned by the API (start date when sorted)
+}
+
+// GroupCourses groups classes into courses by CourseSummary.CourseID, in
+// order of first appearance. Classes without a course summary don't belong
+// to a course and are skipped.
+func GroupCourses(classes []Class) []Course {
+	index := make(map[string]int)
+	var courses []Course
+	for _, c := range classes {
+		if c.CourseSummary == nil || c.CourseSummary.CourseID == "" {
+			continue
+		}
+
+		i, ok := index[c.CourseSummary.CourseID]
+		if !ok {
+			i = len(courses)
+			index[c.CourseSummary.CourseID] = i
+			courses = append(courses, Course{
+				CourseID:   c.CourseSummary.CourseID,
+				Name:       c.CourseSummary.Name,
+				Gender:     c.CourseSummary.Gender,
+				Visibility: c.CourseSummary.Visibility,
+				MinPlayers: c.CourseSummary.MinPlayers,
+				MaxPlayers: c.CourseSummary.MaxPlayers,
+				Tenant:     c.Tenant,
+			})
+		}
+		courses[i].Sessions = append(courses[i].Sessions, c)
+	}
+	return courses
+}
+
+// TotalCapacity returns the number of places across all sessions.
+func (c Course) TotalCapacity() int {
+	return c.MaxPlayers * len(c.Sessions)
+}
+
+// EnrolledCount returns the number of registrations across all sessions.
+func (c Course) EnrolledCount() int {
+	count := 0
+	for _, s := range c.Sessions {
+		count += len(s.RegistrationInfo.Registrations)
+	}
+	return count
+}
+
+// Price returns the base price of the course's sessions, taken from the
+// first session that has one. Sessions of a course share the same price.
+func (c Course) Price() Money {
+	for _, s := range c.Sessions {
+		if !s.RegistrationInfo.BasePrice.IsZero() {
+			return s.RegistrationInfo.BasePrice
+		}
+	}
+	return Money{}
+}
+
+// SessionsWithFreePlaces returns the sessions that have at least one free
+// place left.
+func (c Course) SessionsWithFreePlaces() []Class {
+	var result []Class
+	for _, s := range c.Sessions {
+		if s.FreePlaces() > 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestGroupCourses(t *testing.T) {
+	beginner := &CourseSummary{CourseID: "course-1", Name: "Beginner", MaxPlayers: 4}
+	advanced := &CourseSummary{CourseID: "course-2", Name: "Advanced", MaxPlayers: 2}
+
+	classes := []Class{
+		{AcademyClassID: "class-1", CourseSummary: beginner, RegistrationInfo: RegistrationInfo{BasePrice: MustParseMoney("20 EUR"), Registrations: make([]Registration, 4)}},
+		{AcademyClassID: "class-2", CourseSummary: advanced, RegistrationInfo: RegistrationInfo{Registrations: make([]Registration, 1)}},
+		{AcademyClassID: "class-3", CourseSummary: beginner, RegistrationInfo: RegistrationInfo{BasePrice: MustParseMoney("20 EUR"), Registrations: make([]Registration, 2)}},
+		{AcademyClassID: "class-4"},
+	}
+
+	courses := GroupCourses(classes)
+
+	if len(courses) != 2 {
+		t.Fatalf("Expected 2 courses, got %d", len(courses))
+	}
+
+	course := courses[0]
+	if course.CourseID != "course-1" || course.Name != "Beginner" {
+		t.Errorf("Expected first course 'course-1' (Beginner), got %s (%s)", course.CourseID, course.Name)
+	}
+	if len(course.Sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(course.Sessions))
+	}
+	if course.TotalCapacity() != 8 {
+		t.Errorf("Expected total capacity 8, got %d", course.TotalCapacity())
+	}
+	if course.EnrolledCount() != 6 {
+		t.Errorf("Expected 6 enrolled, got %d", course.EnrolledCount())
+	}
+	if course.Price().String() != "20 EUR" {
+		t.Errorf("Expected price '20 EUR', got %q", course.Price())
+	}
+
+	free := course.SessionsWithFreePlaces()
+	if len(free) != 1 || free[0].AcademyClassID != "class-3" {
+		t.Errorf("Expected only class-3 to have free places, got %+v", free)
+	}
+}
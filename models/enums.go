@@ -0,0 +1,226 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Enum is implemented by the enumerated string types in this package
+// (ClassStatus, Gender, SportID, ...). Values returns every known value;
+// Valid reports whether a value is one of them.
+type Enum[T any] interface {
+	~string
+	Valid() bool
+	Values() []T
+}
+
+// ParseEnum parses a single enum value, rejecting unknown ones with an error
+// that lists the accepted values.
+func ParseEnum[T Enum[T]](s string) (T, error) {
+	v := T(strings.TrimSpace(s))
+	if v.Valid() {
+		return v, nil
+	}
+	return v, unknownEnumError(v)
+}
+
+// ParseEnumList parses a comma-separated list such as "PENDING,IN_PROGRESS",
+// rejecting unknown values. An empty string yields a nil slice.
+func ParseEnumList[T Enum[T]](s string) ([]T, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var values []T
+	for _, part := range strings.Split(s, ",") {
+		v, err := ParseEnum[T](part)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// unknownEnumError describes an unknown value, suggesting the closest known
+// one when it's likely a typo or a casing mistake.
+func unknownEnumError[T Enum[T]](v T) error {
+	known := v.Values()
+	names := make([]string, len(known))
+	for i, k := range known {
+		names[i] = string(k)
+	}
+
+	msg := fmt.Sprintf("unknown value %q (expected one of %s)", string(v), strings.Join(names, ", "))
+	for _, name := range names {
+		if strings.EqualFold(name, string(v)) || editDistance(strings.ToUpper(name), strings.ToUpper(string(v))) <= 2 {
+			msg += fmt.Sprintf("; did you mean %q?", name)
+			break
+		}
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// joinEnums joins enum values into the comma-separated form the API expects,
+// dropping empty values.
+func joinEnums[T ~string](values []T) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if s := strings.TrimSpace(string(v)); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ClassStatus is the status of a class
+type ClassStatus string
+
+const (
+	ClassStatusPending    ClassStatus = "PENDING"
+	ClassStatusInProgress ClassStatus = "IN_PROGRESS"
+	ClassStatusFinished   ClassStatus = "FINISHED"
+	ClassStatusCanceled   ClassStatus = "CANCELED"
+)
+
+var classStatuses = []ClassStatus{ClassStatusPending, ClassStatusInProgress, ClassStatusFinished, ClassStatusCanceled}
+
+func (s ClassStatus) String() string      { return string(s) }
+func (s ClassStatus) Valid() bool         { return slices.Contains(classStatuses, s) }
+func (ClassStatus) Values() []ClassStatus { return slices.Clone(classStatuses) }
+
+// ClassType is the type of a class
+type ClassType string
+
+const (
+	ClassTypeCourse  ClassType = "COURSE"
+	ClassTypePublic  ClassType = "PUBLIC"
+	ClassTypePrivate ClassType = "PRIVATE"
+)
+
+var classTypes = []ClassType{ClassTypeCourse, ClassTypePublic, ClassTypePrivate}
+
+func (t ClassType) String() string    { return string(t) }
+func (t ClassType) Valid() bool       { return slices.Contains(classTypes, t) }
+func (ClassType) Values() []ClassType { return slices.Clone(classTypes) }
+
+// TournamentStatus is the status of a tournament or lesson
+type TournamentStatus string
+
+const (
+	TournamentStatusPending            TournamentStatus = "PENDING"
+	TournamentStatusRegistrationOpen   TournamentStatus = "REGISTRATION_OPEN"
+	TournamentStatusRegistrationClosed TournamentStatus = "REGISTRATION_CLOSED"
+	TournamentStatusInProgress         TournamentStatus = "IN_PROGRESS"
+	TournamentStatusFinished           TournamentStatus = "FINISHED"
+	TournamentStatusCanceled           TournamentStatus = "CANCELED"
+)
+
+var tournamentStatuses = []TournamentStatus{
+	TournamentStatusPending, TournamentStatusRegistrationOpen, TournamentStatusRegistrationClosed,
+	TournamentStatusInProgress, TournamentStatusFinished, TournamentStatusCanceled,
+}
+
+func (s TournamentStatus) String() string           { return string(s) }
+func (s TournamentStatus) Valid() bool              { return slices.Contains(tournamentStatuses, s) }
+func (TournamentStatus) Values() []TournamentStatus { return slices.Clone(tournamentStatuses) }
+
+// RegistrationStatus is whether registration for a tournament or match is open
+type RegistrationStatus string
+
+const (
+	RegistrationStatusOpen   RegistrationStatus = "OPEN"
+	RegistrationStatusClosed RegistrationStatus = "CLOSED"
+)
+
+var registrationStatuses = []RegistrationStatus{RegistrationStatusOpen, RegistrationStatusClosed}
+
+func (s RegistrationStatus) String() string             { return string(s) }
+func (s RegistrationStatus) Valid() bool                { return slices.Contains(registrationStatuses, s) }
+func (RegistrationStatus) Values() []RegistrationStatus { return slices.Clone(registrationStatuses) }
+
+// Gender is the gender restriction of a course, lesson or match
+type Gender string
+
+const (
+	GenderMale   Gender = "MALE"
+	GenderFemale Gender = "FEMALE"
+	GenderMixed  Gender = "MIXED"
+)
+
+var genders = []Gender{GenderMale, GenderFemale, GenderMixed}
+
+func (g Gender) String() string { return string(g) }
+func (g Gender) Valid() bool    { return slices.Contains(genders, g) }
+func (Gender) Values() []Gender { return slices.Clone(genders) }
+
+// Visibility is who can see a course, tournament, lesson or match. Classes
+// and tournaments use PUBLIC/PRIVATE, matches use VISIBLE/HIDDEN.
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "PUBLIC"
+	VisibilityPrivate Visibility = "PRIVATE"
+	VisibilityVisible Visibility = "VISIBLE"
+	VisibilityHidden  Visibility = "HIDDEN"
+)
+
+var visibilities = []Visibility{VisibilityPublic, VisibilityPrivate, VisibilityVisible, VisibilityHidden}
+
+func (v Visibility) String() string     { return string(v) }
+func (v Visibility) Valid() bool        { return slices.Contains(visibilities, v) }
+func (Visibility) Values() []Visibility { return slices.Clone(visibilities) }
+
+// SportID identifies a sport
+type SportID string
+
+const (
+	SportPadel      SportID = "PADEL"
+	SportTennis     SportID = "TENNIS"
+	SportPickleball SportID = "PICKLEBALL"
+	SportSquash     SportID = "SQUASH"
+	SportBadminton  SportID = "BADMINTON"
+)
+
+var sportIDs = []SportID{SportPadel, SportTennis, SportPickleball, SportSquash, SportBadminton}
+
+func (s SportID) String() string  { return string(s) }
+func (s SportID) Valid() bool     { return slices.Contains(sportIDs, s) }
+func (SportID) Values() []SportID { return slices.Clone(sportIDs) }
+
+// MatchType is whether a match counts towards the players' level
+type MatchType string
+
+const (
+	MatchTypeCompetitive MatchType = "COMPETITIVE"
+	MatchTypeFriendly    MatchType = "FRIENDLY"
+)
+
+var matchTypes = []MatchType{MatchTypeCompetitive, MatchTypeFriendly}
+
+func (t MatchType) String() string    { return string(t) }
+func (t MatchType) Valid() bool       { return slices.Contains(matchTypes, t) }
+func (MatchType) Values() []MatchType { return slices.Clone(matchTypes) }
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnumValid(t *testing.T) {
+	if !ClassStatusPending.Valid() {
+		t.Errorf("Expected %s to be valid", ClassStatusPending)
+	}
+	if ClassStatus("PENDIN").Valid() {
+		t.Errorf("Expected PENDIN to be invalid")
+	}
+	if Visibility("public").Valid() {
+		t.Errorf("Expected enum values to be case-sensitive")
+	}
+	if SportPadel.String() != "PADEL" {
+		t.Errorf("Expected String() 'PADEL', got %q", SportPadel.String())
+	}
+}
+
+func TestParseEnumList(t *testing.T) {
+	statuses, err := ParseEnumList[ClassStatus]("PENDING, IN_PROGRESS")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(statuses) != 2 || statuses[0] != ClassStatusPending || statuses[1] != ClassStatusInProgress {
+		t.Errorf("Expected [PENDING IN_PROGRESS], got %v", statuses)
+	}
+
+	if empty, err := ParseEnumList[ClassStatus](""); err != nil || empty != nil {
+		t.Errorf("Expected nil, nil for empty input, got %v, %v", empty, err)
+	}
+
+	_, err = ParseEnumList[ClassStatus]("PENDING,IN_PROGRES")
+	if err == nil {
+		t.Fatal("Expected error for unknown value, got none")
+	}
+	if !strings.Contains(err.Error(), `"IN_PROGRES"`) || !strings.Contains(err.Error(), `did you mean "IN_PROGRESS"?`) {
+		t.Errorf("Expected error to name the value and suggest a fix, got %q", err)
+	}
+
+	_, err = ParseEnum[Gender]("mixed")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "MIXED"?`) {
+		t.Errorf("Expected casing suggestion, got %v", err)
+	}
+
+	_, err = ParseEnum[SportID]("CURLING")
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("Expected error without suggestion for an unrelated value, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package models
+
+import "time"
+
+// EventKind identifies the concrete type behind an Event
+type EventKind string
+
+const (
+	EventKindClass      EventKind = "CLASS"
+	EventKindLesson     EventKind = "LESSON"
+	EventKindMatch      EventKind = "MATCH"
+	EventKindTournament EventKind = "TOURNAMENT"
+)
+
+// Event is the common view over classes, lessons, matches and tournaments,
+// so code that only needs "what, when, where and how many places" can be
+// written once. EventTenant and EventPrice are prefixed to avoid clashing
+// with the Tenant and Price fields of the underlying types.
+type Event interface {
+	ID() string
+	Kind() EventKind
+	Title() string
+	Start() time.Time // in the tenant's time zone
+	End() time.Time   // in the tenant's time zone
+	EventTenant() Tenant
+	Capacity() int
+	Participants() []Player
+	FreePlaces() int
+	EventPrice() Money
+}
+
+var (
+	_ Event = Class{}
+	_ Event = Lesson{}
+	_ Event = Match{}
+	_ Event = Tournament{}
+)
+
+// freePlaces returns capacity minus taken, floored at zero.
+func freePlaces(capacity, taken int) int {
+	return max(capacity-taken, 0)
+}
+
+// ID returns the class ID.
+func (c Class) ID() string { return c.AcademyClassID }
+
+// Kind returns EventKindClass.
+func (c Class) Kind() EventKind { return EventKindClass }
+
+// Title returns the course name, falling back to the court name for classes
+// that aren't part of a course.
+func (c Class) Title() string {
+	if c.CourseSummary != nil && c.CourseSummary.Name != "" {
+		return c.CourseSummary.Name
+	}
+	return c.Resource.Name
+}
+
+// EventTenant returns the class's tenant.
+func (c Class) EventTenant() Tenant { return c.Tenant }
+
+// Capacity returns the course's maximum number of players, or 0 when the
+// class has no course summary to tell.
+func (c Class) Capacity() int {
+	if c.CourseSummary == nil {
+		return 0
+	}
+	return c.CourseSummary.MaxPlayers
+}
+
+// Participants returns the registered players.
+func (c Class) Participants() []Player {
+	players := make([]Player, 0, len(c.RegistrationInfo.Registrations))
+	for _, r := range c.RegistrationInfo.Registrations {
+		players = append(players, r.Player)
+	}
+	return players
+}
+
+// FreePlaces returns Capacity minus registrations. An overbooked class has
+// 0, like a full one, so watchers tracking it notify only once a place is
+// actually free rather than when it drops back to full.
+func (c Class) FreePlaces() int {
+	return freePlaces(c.Capacity(), len(c.RegistrationInfo.Registrations))
+}
+
+// EventPrice returns the base price per player.
+func (c Class) EventPrice() Money { return c.RegistrationInfo.BasePrice }
+
+// ID returns the lesson's tournament ID.
+func (l Lesson) ID() string { return l.TournamentID }
+
+// Kind returns EventKindLesson.
+func (l Lesson) Kind() EventKind { return EventKindLesson }
+
+// Title returns the lesson name.
+func (l Lesson) Title() string { return l.TournamentName }
+
+// EventTenant returns the lesson's tenant as a Tenant.
+func (l Lesson) EventTenant() Tenant { return LessonTenantToTenant(&l.Tenant) }
+
+// Capacity returns the maximum number of players.
+func (l Lesson) Capacity() int { return l.MaxPlayers }
+
+// Participants returns the registered players as Players.
+func (l Lesson) Participants() []Player {
+	players := make([]Player, 0, len(l.RegisteredPlayers))
+	for i := range l.RegisteredPlayers {
+		players = append(players, LessonPlayerToPlayer(&l.RegisteredPlayers[i]))
+	}
+	return players
+}
+
+// FreePlaces returns the available places reported by the API.
+func (l Lesson) FreePlaces() int { return l.AvailablePlaces }
+
+// EventPrice returns the price per player.
+func (l Lesson) EventPrice() Money { return l.Price }
+
+// ID returns the match ID.
+func (m Match) ID() string { return m.MatchID }
+
+// Kind returns EventKindMatch.
+func (m Match) Kind() EventKind { return EventKindMatch }
+
+// Title returns the match location, falling back to the tenant name.
+func (m Match) Title() string {
+	switch {
+	case m.LocationInfo.Name != "":
+		return m.LocationInfo.Name
+	case m.Location != "":
+		return m.Location
+	}
+	return m.Tenant.TenantName
+}
+
+// EventTenant returns the match's tenant.
+func (m Match) EventTenant() Tenant { return m.Tenant }
+
+// Capacity returns the total number of positions across teams.
+func (m Match) Capacity() int {
+	capacity := 0
+	for _, t := range m.Teams {
+		capacity += t.MaxPlayers
+	}
+	return capacity
+}
+
+// Participants returns the players of every team.
+func (m Match) Participants() []Player {
+	var players []Player
+	for _, t := range m.Teams {
+		players = append(players, t.Players...)
+	}
+	return players
+}
+
+// FreePlaces returns Capacity minus the players already in a team.
+func (m Match) FreePlaces() int {
+	return freePlaces(m.Capacity(), len(m.Participants()))
+}
+
+// EventPrice returns the price per player.
+func (m Match) EventPrice() Money { return m.Price }
+
+// ID returns the tournament ID.
+func (t Tournament) ID() string { return t.TournamentID }
+
+// Kind returns EventKindTournament.
+func (t Tournament) Kind() EventKind { return EventKindTournament }
+
+// Title returns the tournament name.
+func (t Tournament) Title() string { return t.Name }
+
+// EventTenant returns the tournament's tenant as a Tenant.
+func (t Tournament) EventTenant() Tenant { return LessonTenantToTenant(&t.Tenant) }
+
+// Capacity returns registered players plus available places; the API
+// doesn't report the maximum directly.
+func (t Tournament) Capacity() int {
+	return len(t.Participants()) + t.AvailablePlaces
+}
+
+// Participants returns the players of every team as Players.
+func (t Tournament) Participants() []Player {
+	var players []Player
+	for _, team := range t.Teams {
+		for i := range team.Players {
+			players = append(players, TournamentPlayerToPlayer(&team.Players[i]))
+		}
+	}
+	return players
+}
+
+// FreePlaces returns the available places reported by the API.
+func (t Tournament) FreePlaces() int { return t.AvailablePlaces }
+
+// EventPrice returns the registration price.
+func (t Tournament) EventPrice() Money { return t.Price }
@@ -0,0 +1,117 @@
+package models
+
+import "testing"
+
+func TestEventImplementations(t *testing.T) {
+	price := MustParseMoney("20 EUR")
+	tests := []struct {
+		name         string
+		event        Event
+		kind         EventKind
+		id           string
+		title        string
+		tenantName   string
+		capacity     int
+		participants int
+		freePlaces   int
+	}{
+		{
+			name: "class",
+			event: Class{
+				AcademyClassID:   "class-1",
+				CourseSummary:    &CourseSummary{Name: "Intermediate", MaxPlayers: 4},
+				RegistrationInfo: RegistrationInfo{BasePrice: price, Registrations: []Registration{{Player: Player{Name: "Ana"}}}},
+				Tenant:           Tenant{TenantName: "Test Club"},
+			},
+			kind: EventKindClass, id: "class-1", title: "Intermediate", tenantName: "Test Club",
+			capacity: 4, participants: 1, freePlaces: 3,
+		},
+		{
+			name:  "class without course summary",
+			event: Class{AcademyClassID: "class-2", Resource: Resource{Name: "Court 1"}},
+			kind:  EventKindClass, id: "class-2", title: "Court 1",
+		},
+		{
+			name: "lesson",
+			event: Lesson{
+				TournamentID:      "lesson-1",
+				TournamentName:    "Clinic",
+				MaxPlayers:        8,
+				AvailablePlaces:   6,
+				Price:             price,
+				RegisteredPlayers: []LessonPlayer{{FullName: "Ana"}, {FullName: "Ben"}},
+				Tenant:            LessonTenant{TenantName: "Test Club"},
+			},
+			kind: EventKindLesson, id: "lesson-1", title: "Clinic", tenantName: "Test Club",
+			capacity: 8, participants: 2, freePlaces: 6,
+		},
+		{
+			name: "match",
+			event: Match{
+				MatchID:      "match-1",
+				LocationInfo: LocationInfo{Name: "Court 3"},
+				Price:        price,
+				Teams: []Team{
+					{MaxPlayers: 2, Players: []Player{{Name: "Ana"}, {Name: "Ben"}}},
+					{MaxPlayers: 2, Players: []Player{{Name: "Cem"}}},
+				},
+				Tenant: Tenant{TenantName: "Test Club"},
+			},
+			kind: EventKindMatch, id: "match-1", title: "Court 3", tenantName: "Test Club",
+			capacity: 4, participants: 3, freePlaces: 1,
+		},
+		{
+			name: "tournament",
+			event: Tournament{
+				TournamentID:    "tournament-1",
+				Name:            "Americano",
+				AvailablePlaces: 2,
+				Price:           price,
+				Teams:           []TournamentTeam{{Players: []TournamentPlayer{{Name: "Ana", UserID: "user-1"}, {Name: "Ben"}}}},
+				Tenant:          LessonTenant{TenantName: "Test Club"},
+			},
+			kind: EventKindTournament, id: "tournament-1", title: "Americano", tenantName: "Test Club",
+			capacity: 4, participants: 2, freePlaces: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.event
+			if e.Kind() != tt.kind {
+				t.Errorf("Expected kind %s, got %s", tt.kind, e.Kind())
+			}
+			if e.ID() != tt.id {
+				t.Errorf("Expected ID %s, got %s", tt.id, e.ID())
+			}
+			if e.Title() != tt.title {
+				t.Errorf("Expected title %q, got %q", tt.title, e.Title())
+			}
+			if e.EventTenant().TenantName != tt.tenantName {
+				t.Errorf("Expected tenant %q, got %q", tt.tenantName, e.EventTenant().TenantName)
+			}
+			if e.Capacity() != tt.capacity {
+				t.Errorf("Expected capacity %d, got %d", tt.capacity, e.Capacity())
+			}
+			if len(e.Participants()) != tt.participants {
+				t.Errorf("Expected %d participants, got %d", tt.participants, len(e.Participants()))
+			}
+			if e.FreePlaces() != tt.freePlaces {
+				t.Errorf("Expected %d free places, got %d", tt.freePlaces, e.FreePlaces())
+			}
+			if tt.capacity > 0 && !e.EventPrice().Equal(price) {
+				t.Errorf("Expected price %s, got %s", price, e.EventPrice())
+			}
+		})
+	}
+}
+
+func TestFreePlacesNeverNegative(t *testing.T) {
+	c := Class{
+		CourseSummary:    &CourseSummary{MaxPlayers: 2},
+		RegistrationInfo: RegistrationInfo{Registrations: make([]Registration, 3)},
+	}
+	if c.FreePlaces() != 0 {
+		t.Errorf("Expected 0 free places for an overbooked class, got %d", c.FreePlaces())
+	}
+}
@@ -0,0 +1,155 @@
+package fixtures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// ClassBuilder builds a models.Class.
+type ClassBuilder struct {
+	f        *Factory
+	c        models.Class
+	duration time.Duration
+}
+
+// Class returns a builder for a pending one-hour course session with four
+// places and no registrations.
+func Class() *ClassBuilder { return (*Factory)(nil).Class() }
+
+// Class returns a class builder with a random ID, course, club and start.
+func (f *Factory) Class() *ClassBuilder {
+	b := &ClassBuilder{
+		f:        f,
+		duration: time.Hour,
+		c: models.Class{
+			Type:           models.ClassTypeCourse,
+			AcademyClassID: f.id("class", 1),
+			SportID:        models.SportPadel,
+			Tenant:         f.Tenant().Build(),
+			Resource: models.Resource{
+				ID:         f.id("resource", 1),
+				Name:       "Court 1",
+				Properties: models.Properties{"resource_type": "indoor", "resource_size": "double"},
+			},
+			RegistrationInfo: models.RegistrationInfo{
+				PaymentType: "BY_PLAYER",
+				BasePrice:   mustMoney("20 EUR"),
+			},
+			CourseSummary: &models.CourseSummary{
+				CourseID:   f.id("course", 1),
+				Name:       f.pick("Test Course", courseNames),
+				Gender:     models.GenderMixed,
+				Visibility: models.VisibilityPublic,
+				MinPlayers: 1,
+				MaxPlayers: 4,
+			},
+			Origin: "ACADEMY",
+			Status: models.ClassStatusPending,
+		},
+	}
+	return b.Starting(f.start())
+}
+
+// WithID sets the academy class ID.
+func (b *ClassBuilder) WithID(id string) *ClassBuilder {
+	b.c.AcademyClassID = id
+	return b
+}
+
+// InCourse sets the course the class belongs to.
+func (b *ClassBuilder) InCourse(id, name string) *ClassBuilder {
+	b.ensureSummary()
+	b.c.CourseSummary.CourseID = id
+	b.c.CourseSummary.Name = name
+	return b
+}
+
+// WithoutCourse drops the course summary, as the API does for public and
+// private classes.
+func (b *ClassBuilder) WithoutCourse() *ClassBuilder {
+	b.c.CourseSummary = nil
+	return b
+}
+
+// WithMaxPlayers sets the class capacity.
+func (b *ClassBuilder) WithMaxPlayers(n int) *ClassBuilder {
+	b.ensureSummary()
+	b.c.CourseSummary.MaxPlayers = n
+	return b
+}
+
+// WithCoach adds a coach.
+func (b *ClassBuilder) WithCoach(name string) *ClassBuilder {
+	b.c.Coaches = append(b.c.Coaches, models.Coach{
+		UserID: "coach-" + slug(name),
+		Name:   name,
+	})
+	return b
+}
+
+// WithRegistrations adds n registered players.
+func (b *ClassBuilder) WithRegistrations(n int) *ClassBuilder {
+	for i := 0; i < n; i++ {
+		b.WithPlayer(b.f.Player(len(b.c.RegistrationInfo.Registrations) + 1))
+	}
+	return b
+}
+
+// WithPlayer registers p.
+func (b *ClassBuilder) WithPlayer(p models.Player) *ClassBuilder {
+	regs := &b.c.RegistrationInfo.Registrations
+	*regs = append(*regs, models.Registration{
+		ClassRegistrationID: fmt.Sprintf("%s-registration-%d", b.c.AcademyClassID, len(*regs)+1),
+		Player:              p,
+		Price:               b.c.RegistrationInfo.BasePrice.String(),
+		RegistrationDate:    models.NewTime(b.c.StartDate.AddDate(0, 0, -7)),
+	})
+	b.c.RegistrationInfo.NumberOfPlayers = len(*regs)
+	return b
+}
+
+// Starting sets the start time, keeping the duration.
+func (b *ClassBuilder) Starting(t time.Time) *ClassBuilder {
+	b.c.StartDate = models.NewTime(t)
+	b.c.EndDate = models.NewTime(t.Add(b.duration))
+	return b
+}
+
+// Lasting sets the duration.
+func (b *ClassBuilder) Lasting(d time.Duration) *ClassBuilder {
+	b.duration = d
+	b.c.EndDate = models.NewTime(b.c.StartDate.Add(d))
+	return b
+}
+
+// WithStatus sets the class status.
+func (b *ClassBuilder) WithStatus(s models.ClassStatus) *ClassBuilder {
+	b.c.Status = s
+	return b
+}
+
+// WithType sets the class type.
+func (b *ClassBuilder) WithType(t models.ClassType) *ClassBuilder {
+	b.c.Type = t
+	return b
+}
+
+// AtTenant sets the club.
+func (b *ClassBuilder) AtTenant(t models.Tenant) *ClassBuilder {
+	b.c.Tenant = t
+	return b
+}
+
+// Build returns the class.
+func (b *ClassBuilder) Build() models.Class { return b.c }
+
+// JSON returns the class as the API sends it.
+func (b *ClassBuilder) JSON() []byte { return JSON(b.c) }
+
+func (b *ClassBuilder) ensureSummary() {
+	if b.c.CourseSummary == nil {
+		b.c.CourseSummary = &models.CourseSummary{MaxPlayers: 4}
+	}
+}
@@ -0,0 +1,78 @@
+package fixtures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// CourtBuilder builds a models.CourtAvailability.
+type CourtBuilder struct {
+	f *Factory
+	c models.CourtAvailability
+}
+
+// Court returns a builder for a court with no free slots on DefaultStart's
+// date.
+func Court() *CourtBuilder { return (*Factory)(nil).Court() }
+
+// Court returns a court builder with a random resource ID and date.
+func (f *Factory) Court() *CourtBuilder {
+	return &CourtBuilder{f: f, c: models.CourtAvailability{
+		ResourceID: f.id("resource", 1),
+		StartDate:  models.NewDate(f.start()),
+	}}
+}
+
+// WithResource sets the court's resource ID.
+func (b *CourtBuilder) WithResource(id string) *CourtBuilder {
+	b.c.ResourceID = id
+	return b
+}
+
+// On sets the date, as "2006-01-02".
+func (b *CourtBuilder) On(date string) *CourtBuilder {
+	b.c.StartDate = models.MustParseDate(date)
+	return b
+}
+
+// OnDay sets the date to t's UTC calendar day.
+func (b *CourtBuilder) OnDay(t time.Time) *CourtBuilder {
+	b.c.StartDate = models.NewDate(t)
+	return b
+}
+
+// WithSlot adds a free slot starting at start ("17:00" or "17:00:00", UTC
+// like the API) lasting minutes, at price ("36 EUR").
+func (b *CourtBuilder) WithSlot(start string, minutes int, price string) *CourtBuilder {
+	tod, err := models.ParseTimeOfDay(start)
+	if err != nil {
+		panic(fmt.Sprintf("fixtures: %v", err))
+	}
+	b.c.Slots = append(b.c.Slots, models.Slot{StartTime: tod, Duration: minutes, Price: mustMoney(price)})
+	return b
+}
+
+// WithRandomSlots adds n slots at random half hours between 08:00 and
+// 21:30, of 60, 90 or 120 minutes. Without a seeded Factory the slots are
+// 90-minute ones every hour from 08:00.
+func (b *CourtBuilder) WithRandomSlots(n int) *CourtBuilder {
+	durations := []int{60, 90, 120}
+	for i := 0; i < n; i++ {
+		if b.f == nil {
+			b.WithSlot(fmt.Sprintf("%02d:00", 8+i%14), 90, "36 EUR")
+			continue
+		}
+		halfHours := 16 + b.f.rng.Intn(28)
+		minutes := durations[b.f.rng.Intn(len(durations))]
+		b.WithSlot(fmt.Sprintf("%02d:%02d", halfHours/2, halfHours%2*30), minutes, fmt.Sprintf("%d EUR", minutes*2/5))
+	}
+	return b
+}
+
+// Build returns the court availability.
+func (b *CourtBuilder) Build() models.CourtAvailability { return b.c }
+
+// JSON returns the court availability as the API sends it.
+func (b *CourtBuilder) JSON() []byte { return JSON(b.c) }
@@ -0,0 +1,110 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+func TestDefaults(t *testing.T) {
+	start := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+	c := Class().WithCoach("Ana").WithRegistrations(3).Starting(start).Build()
+
+	if c.AcademyClassID != "class-1" || c.Tenant.TenantName != "Test Club" {
+		t.Errorf("Unexpected defaults: %s at %s", c.AcademyClassID, c.Tenant.TenantName)
+	}
+	if c.Coaches[0].Name != "Ana" {
+		t.Errorf("Expected coach Ana, got %+v", c.Coaches)
+	}
+	if c.FreePlaces() != 1 {
+		t.Errorf("Expected 1 free place, got %d", c.FreePlaces())
+	}
+	if !c.StartDate.Equal(start) || !c.EndDate.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected 18:00-19:00, got %s-%s", c.StartDate, c.EndDate)
+	}
+
+	court := Court().WithSlot("17:00", 90, "36 EUR").Build()
+	if court.Slots[0].StartTime.String() != "17:00:00" || court.Slots[0].Price.String() != "36 EUR" {
+		t.Errorf("Unexpected slot %+v", court.Slots[0])
+	}
+
+	m := Match().WithPlayers(3).Build()
+	if m.FreePositions() != 1 || len(m.Teams[0].Players) != 2 {
+		t.Errorf("Expected team 0 full and one free position, got %+v", m.Teams)
+	}
+
+	l := Lesson().WithRegistrations(4).Build()
+	if l.AvailablePlaces != 0 {
+		t.Errorf("Expected a full lesson, got %d places", l.AvailablePlaces)
+	}
+
+	tr := Tournament().WithTeam("Ana", "Ben").Build()
+	if tr.AvailablePlaces != 6 || tr.Teams[0].Players[1].UserID != "user-ben" {
+		t.Errorf("Unexpected tournament %+v", tr)
+	}
+}
+
+func TestFactoryIsDeterministic(t *testing.T) {
+	build := func(seed int64) []byte {
+		f := New(seed)
+		return JSON([]interface{}{
+			f.Class().WithRegistrations(2).Build(),
+			f.Match().WithPlayers(2).Build(),
+			f.Court().WithRandomSlots(5).Build(),
+			f.Lesson().Build(),
+			f.Tournament().Build(),
+		})
+	}
+
+	if !bytes.Equal(build(42), build(42)) {
+		t.Error("Expected the same seed to give the same fixtures")
+	}
+	if bytes.Equal(build(42), build(43)) {
+		t.Error("Expected different seeds to give different fixtures")
+	}
+}
+
+func TestFactoryValuesAreValid(t *testing.T) {
+	f := New(7)
+	for i := 0; i < 100; i++ {
+		m := f.Match().WithPlayers(4).Build()
+		if m.MinLevel < 1 || m.MaxLevel > 6 || m.MinLevel >= m.MaxLevel {
+			t.Fatalf("Invalid level range %.1f-%.1f", m.MinLevel, m.MaxLevel)
+		}
+		for _, p := range m.Participants() {
+			if p.LevelValue < 1 || p.LevelValue > 6 {
+				t.Fatalf("Invalid player level %.2f", p.LevelValue)
+			}
+		}
+		if h := m.StartDate.Hour(); h < 8 || h > 21 {
+			t.Fatalf("Start %s outside 08:00-21:30", m.StartDate)
+		}
+
+		for _, s := range f.Court().WithRandomSlots(3).Build().Slots {
+			if s.Duration != 60 && s.Duration != 90 && s.Duration != 120 {
+				t.Fatalf("Unexpected slot duration %d", s.Duration)
+			}
+		}
+	}
+}
+
+func TestJSONRoundTrips(t *testing.T) {
+	want := Class().WithCoach("Ana").WithRegistrations(2).Build()
+
+	var got models.Class
+	if err := json.Unmarshal(Class().WithCoach("Ana").WithRegistrations(2).JSON(), &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(JSON(got), JSON(want)) {
+		t.Errorf("Round trip changed the class:\n got %s\nwant %s", JSON(got), JSON(want))
+	}
+
+	// Dates use the API's layout, not RFC 3339.
+	if !bytes.Contains(JSON(want), []byte(`"start_date":"2026-04-10T10:00:00"`)) {
+		t.Errorf("Expected API timestamp layout in %s", JSON(want))
+	}
+}
@@ -0,0 +1,207 @@
+package fixtures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// LessonBuilder builds a models.Lesson.
+type LessonBuilder struct {
+	f        *Factory
+	l        models.Lesson
+	duration time.Duration
+}
+
+// Lesson returns a builder for a one-hour lesson open for registration,
+// with four places and registration closing a day before it starts.
+func Lesson() *LessonBuilder { return (*Factory)(nil).Lesson() }
+
+// Lesson returns a lesson builder with a random ID, name, club and start.
+func (f *Factory) Lesson() *LessonBuilder {
+	b := &LessonBuilder{
+		f:        f,
+		duration: time.Hour,
+		l: models.Lesson{
+			TournamentID:         f.id("lesson", 1),
+			TournamentName:       f.pick("Test Lesson", courseNames),
+			Type:                 "LESSON",
+			MinPlayers:           2,
+			MaxPlayers:           4,
+			Price:                mustMoney("15 EUR"),
+			SportID:              models.SportPadel,
+			Gender:               models.GenderMixed,
+			TournamentVisibility: models.VisibilityPublic,
+			TournamentStatus:     models.TournamentStatusRegistrationOpen,
+			AvailablePlaces:      4,
+			Tenant:               f.Tenant().BuildLessonTenant(),
+		},
+	}
+	return b.Starting(f.start())
+}
+
+// WithID sets the lesson ID.
+func (b *LessonBuilder) WithID(id string) *LessonBuilder {
+	b.l.TournamentID = id
+	return b
+}
+
+// WithName sets the lesson name.
+func (b *LessonBuilder) WithName(name string) *LessonBuilder {
+	b.l.TournamentName = name
+	return b
+}
+
+// WithCoach adds a coach.
+func (b *LessonBuilder) WithCoach(name string) *LessonBuilder {
+	b.l.Coaches = append(b.l.Coaches, models.Coach{UserID: "coach-" + slug(name), Name: name})
+	return b
+}
+
+// WithRegistrations registers n generated players, updating the available
+// places.
+func (b *LessonBuilder) WithRegistrations(n int) *LessonBuilder {
+	for i := 0; i < n; i++ {
+		p := b.f.Player(len(b.l.RegisteredPlayers) + 1)
+		b.l.RegisteredPlayers = append(b.l.RegisteredPlayers, models.LessonPlayer{
+			UserID:            p.UserID,
+			PaymentID:         fmt.Sprintf("payment-%d", len(b.l.RegisteredPlayers)+1),
+			RegistrationPrice: b.l.Price.String(),
+			FullName:          p.Name,
+			LevelValue:        p.LevelValue,
+		})
+	}
+	b.l.AvailablePlaces = max(b.l.MaxPlayers-len(b.l.RegisteredPlayers), 0)
+	return b
+}
+
+// WithLevel sets the level description, e.g. "Intermediate".
+func (b *LessonBuilder) WithLevel(description string) *LessonBuilder {
+	b.l.LevelDescription = description
+	return b
+}
+
+// WithTags adds tags.
+func (b *LessonBuilder) WithTags(tags ...string) *LessonBuilder {
+	b.l.Tags = append(b.l.Tags, tags...)
+	return b
+}
+
+// WithGender sets the gender restriction.
+func (b *LessonBuilder) WithGender(g models.Gender) *LessonBuilder {
+	b.l.Gender = g
+	return b
+}
+
+// WithPrice sets the price per player, e.g. "20 EUR".
+func (b *LessonBuilder) WithPrice(price string) *LessonBuilder {
+	b.l.Price = mustMoney(price)
+	return b
+}
+
+// Cancelled marks the lesson as cancelled.
+func (b *LessonBuilder) Cancelled() *LessonBuilder {
+	b.l.IsCancelled = true
+	return b
+}
+
+// Starting sets the start time, keeping the duration, and closes
+// registration a day earlier.
+func (b *LessonBuilder) Starting(t time.Time) *LessonBuilder {
+	b.l.StartDate = models.NewTime(t)
+	b.l.EndDate = models.NewTime(t.Add(b.duration))
+	b.l.RegistrationClosingTime = models.NewTime(t.AddDate(0, 0, -1))
+	return b
+}
+
+// ClosingRegistration sets when registration closes.
+func (b *LessonBuilder) ClosingRegistration(t time.Time) *LessonBuilder {
+	b.l.RegistrationClosingTime = models.NewTime(t)
+	return b
+}
+
+// WithStatus sets the lesson status.
+func (b *LessonBuilder) WithStatus(s models.TournamentStatus) *LessonBuilder {
+	b.l.TournamentStatus = s
+	return b
+}
+
+// AtTenant sets the club.
+func (b *LessonBuilder) AtTenant(t models.LessonTenant) *LessonBuilder {
+	b.l.Tenant = t
+	return b
+}
+
+// Build returns the lesson.
+func (b *LessonBuilder) Build() models.Lesson { return b.l }
+
+// JSON returns the lesson as the API sends it.
+func (b *LessonBuilder) JSON() []byte { return JSON(b.l) }
+
+// TournamentBuilder builds a models.Tournament.
+type TournamentBuilder struct {
+	f *Factory
+	t models.Tournament
+}
+
+// Tournament returns a builder for a public, pending one-day tournament
+// with eight places and no teams.
+func Tournament() *TournamentBuilder { return (*Factory)(nil).Tournament() }
+
+// Tournament returns a tournament builder with a random ID, club and start.
+func (f *Factory) Tournament() *TournamentBuilder {
+	b := &TournamentBuilder{f: f, t: models.Tournament{
+		TournamentID:    f.id("tournament", 1),
+		Name:            f.pick("Test Tournament", []string{"Spring Open", "Summer Cup", "Night Americano", "Club Championship"}),
+		Visibility:      models.VisibilityPublic,
+		AvailablePlaces: 8,
+		Status:          models.TournamentStatusPending,
+		Price:           mustMoney("25 EUR"),
+		Tenant:          f.Tenant().BuildLessonTenant(),
+	}}
+	return b.Starting(f.start())
+}
+
+// WithID sets the tournament ID.
+func (b *TournamentBuilder) WithID(id string) *TournamentBuilder {
+	b.t.TournamentID = id
+	return b
+}
+
+// WithName sets the tournament name.
+func (b *TournamentBuilder) WithName(name string) *TournamentBuilder {
+	b.t.Name = name
+	return b
+}
+
+// WithTeam adds a team of the named players, taking places from
+// AvailablePlaces.
+func (b *TournamentBuilder) WithTeam(names ...string) *TournamentBuilder {
+	team := models.TournamentTeam{TeamID: fmt.Sprintf("team-%d", len(b.t.Teams)+1)}
+	for _, name := range names {
+		team.Players = append(team.Players, models.TournamentPlayer{Name: name, UserID: "user-" + slug(name)})
+	}
+	b.t.Teams = append(b.t.Teams, team)
+	b.t.AvailablePlaces = max(b.t.AvailablePlaces-len(names), 0)
+	return b
+}
+
+// WithAvailablePlaces sets the free places.
+func (b *TournamentBuilder) WithAvailablePlaces(n int) *TournamentBuilder {
+	b.t.AvailablePlaces = n
+	return b
+}
+
+// Starting sets the start time; tournaments last six hours.
+func (b *TournamentBuilder) Starting(t time.Time) *TournamentBuilder {
+	b.t.StartDate = models.NewTime(t)
+	b.t.EndDate = models.NewTime(t.Add(6 * time.Hour))
+	return b
+}
+
+// Build returns the tournament.
+func (b *TournamentBuilder) Build() models.Tournament { return b.t }
+
+// JSON returns the tournament as the API sends it.
+func (b *TournamentBuilder) JSON() []byte { return JSON(b.t) }
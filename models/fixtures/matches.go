@@ -0,0 +1,124 @@
+package fixtures
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// MatchBuilder builds a models.Match.
+type MatchBuilder struct {
+	f        *Factory
+	m        models.Match
+	duration time.Duration
+}
+
+// Match returns a builder for an empty, open, mixed 2v2 padel match of 90
+// minutes for levels 2.0 to 4.0.
+func Match() *MatchBuilder { return (*Factory)(nil).Match() }
+
+// Match returns a match builder with a random ID, club, start and level
+// range.
+func (f *Factory) Match() *MatchBuilder {
+	minLevel := 2.0
+	if f != nil {
+		minLevel = float64(2+f.rng.Intn(7)) / 2 // 1.0 to 4.0 in half steps
+	}
+	b := &MatchBuilder{
+		f:        f,
+		duration: 90 * time.Minute,
+		m: models.Match{
+			MatchID:            f.id("match", 1),
+			SportID:            models.SportPadel,
+			MinPlayersPerTeam:  2,
+			MaxPlayersPerTeam:  2,
+			Status:             "PENDING",
+			Tenant:             f.Tenant().Build(),
+			MatchType:          models.MatchTypeCompetitive,
+			Gender:             models.GenderMixed,
+			MinLevel:           minLevel,
+			MaxLevel:           minLevel + 2,
+			Price:              mustMoney("36 EUR"),
+			RegistrationStatus: models.RegistrationStatusOpen,
+			Visibility:         models.VisibilityVisible,
+			Teams: []models.Team{
+				{TeamID: "0", MinPlayers: 2, MaxPlayers: 2},
+				{TeamID: "1", MinPlayers: 2, MaxPlayers: 2},
+			},
+			ResourceProperties: models.ResourceProperties{ResourceType: "indoor", ResourceSize: "double"},
+		},
+	}
+	b.m.CreatedAt = models.NewTime(DefaultStart.AddDate(0, 0, -3))
+	return b.Starting(f.start())
+}
+
+// WithID sets the match ID.
+func (b *MatchBuilder) WithID(id string) *MatchBuilder {
+	b.m.MatchID = id
+	return b
+}
+
+// WithPlayer adds p to team (0 or 1).
+func (b *MatchBuilder) WithPlayer(team int, p models.Player) *MatchBuilder {
+	for len(b.m.Teams) <= team {
+		b.m.Teams = append(b.m.Teams, models.Team{TeamID: strconv.Itoa(len(b.m.Teams)), MinPlayers: 2, MaxPlayers: 2})
+	}
+	b.m.Teams[team].Players = append(b.m.Teams[team].Players, p)
+	return b
+}
+
+// WithPlayers adds n generated players, filling team 0 first.
+func (b *MatchBuilder) WithPlayers(n int) *MatchBuilder {
+	for i := 0; i < n; i++ {
+		team := 0
+		if len(b.m.Teams[0].Players) >= b.m.Teams[0].MaxPlayers {
+			team = 1
+		}
+		b.WithPlayer(team, b.f.Player(len(b.m.Participants())+1))
+	}
+	return b
+}
+
+// Levels sets the level range.
+func (b *MatchBuilder) Levels(min, max float64) *MatchBuilder {
+	b.m.MinLevel, b.m.MaxLevel = min, max
+	return b
+}
+
+// WithGender sets the gender restriction.
+func (b *MatchBuilder) WithGender(g models.Gender) *MatchBuilder {
+	b.m.Gender = g
+	return b
+}
+
+// WithPrice sets the price per player, e.g. "9 EUR".
+func (b *MatchBuilder) WithPrice(price string) *MatchBuilder {
+	b.m.Price = mustMoney(price)
+	return b
+}
+
+// Closed closes registration.
+func (b *MatchBuilder) Closed() *MatchBuilder {
+	b.m.RegistrationStatus = models.RegistrationStatusClosed
+	return b
+}
+
+// Starting sets the start time, keeping the duration.
+func (b *MatchBuilder) Starting(t time.Time) *MatchBuilder {
+	b.m.StartDate = models.NewTime(t)
+	b.m.EndDate = models.NewTime(t.Add(b.duration))
+	return b
+}
+
+// AtTenant sets the club.
+func (b *MatchBuilder) AtTenant(t models.Tenant) *MatchBuilder {
+	b.m.Tenant = t
+	return b
+}
+
+// Build returns the match.
+func (b *MatchBuilder) Build() models.Match { return b.m }
+
+// JSON returns the match as the API sends it.
+func (b *MatchBuilder) JSON() []byte { return JSON(b.m) }
@@ -0,0 +1,115 @@
+package fixtures
+
+import (
+	"fmt"
+
+	"github.com/rafa-garcia/go-playtomic-api/models"
+)
+
+// TenantBuilder builds a models.Tenant.
+type TenantBuilder struct {
+	t models.Tenant
+}
+
+// Tenant returns a builder for a club in Berlin.
+func Tenant() *TenantBuilder { return (*Factory)(nil).Tenant() }
+
+// Tenant returns a builder for a club in Berlin with a random ID and name.
+func (f *Factory) Tenant() *TenantBuilder {
+	return &TenantBuilder{t: models.Tenant{
+		TenantID:   f.id("tenant", 1),
+		TenantName: f.pick("Test Club", clubNames),
+		Address: models.Address{
+			Street:      "Teststraße 1",
+			PostalCode:  "10115",
+			City:        "Berlin",
+			Country:     "Germany",
+			CountryCode: "DE",
+			Coordinate:  models.Coordinate{Lat: 52.52, Lon: 13.405},
+			Timezone:    "Europe/Berlin",
+		},
+		Properties:      models.Properties{},
+		PlaytomicStatus: "ACTIVE",
+	}}
+}
+
+// WithID sets the tenant ID.
+func (b *TenantBuilder) WithID(id string) *TenantBuilder {
+	b.t.TenantID = id
+	return b
+}
+
+// WithName sets the club name.
+func (b *TenantBuilder) WithName(name string) *TenantBuilder {
+	b.t.TenantName = name
+	return b
+}
+
+// WithTimezone sets the club's IANA time zone.
+func (b *TenantBuilder) WithTimezone(tz string) *TenantBuilder {
+	b.t.Address.Timezone = tz
+	return b
+}
+
+// At sets the club's coordinate.
+func (b *TenantBuilder) At(c models.Coordinate) *TenantBuilder {
+	b.t.Address.Coordinate = c
+	return b
+}
+
+// Build returns the tenant.
+func (b *TenantBuilder) Build() models.Tenant { return b.t }
+
+// BuildLessonTenant returns the tenant in the shape lessons and tournaments
+// use.
+func (b *TenantBuilder) BuildLessonTenant() models.LessonTenant {
+	return models.LessonTenant{
+		TenantID:      b.t.TenantID,
+		TenantName:    b.t.TenantName,
+		TenantAddress: b.t.Address,
+		TenantImages:  b.t.Images,
+		Properties:    b.t.Properties,
+	}
+}
+
+// Player returns a player with the given name and level.
+func Player(name string, level float64) models.Player {
+	return models.Player{
+		BasePlayer: models.BasePlayer{
+			UserID:     "user-" + slug(name),
+			LevelValue: level,
+		},
+		Name: name,
+	}
+}
+
+// Player returns the n-th (1-based) generated player: "Player n" at level
+// 3.0 by default, or a random name and level.
+func (f *Factory) Player(n int) models.Player {
+	p := Player(f.playerName(n), f.level(3.0))
+	p.UserID = f.id("user", n)
+	return p
+}
+
+// slug lowercases name and replaces anything but letters and digits with
+// dashes, for readable derived IDs.
+func slug(name string) string {
+	out := make([]rune, 0, len(name))
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			out = append(out, r)
+		case r >= 'A' && r <= 'Z':
+			out = append(out, r+'a'-'A')
+		case len(out) > 0 && out[len(out)-1] != '-':
+			out = append(out, '-')
+		}
+	}
+	if len(out) > 0 && out[len(out)-1] == '-' {
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return fmt.Sprintf("%x", name)
+	}
+	return string(out)
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// earthRadius is the mean Earth radius in meters.
+const earthRadius = 6371008.8
+
+// IsZero reports whether c is unset. The API sends 0,0 for venues without a
+// known location.
+func (c Coordinate) IsZero() bool {
+	return c.Lat == 0 && c.Lon == 0
+}
+
+// DistanceTo returns the great-circle (haversine) distance to o in meters.
+func (c Coordinate) DistanceTo(o Coordinate) float64 {
+	lat1, lat2 := radians(c.Lat), radians(o.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(o.Lon - c.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Within reports whether o is at most radius meters from c.
+func (c Coordinate) Within(o Coordinate, radius float64) bool {
+	return c.DistanceTo(o) <= radius
+}
+
+// BoundingBox is a latitude/longitude rectangle.
+type BoundingBox struct {
+	Min Coordinate // south-west corner
+	Max Coordinate // north-east corner
+}
+
+// BoundingBox returns the smallest box containing every point within radius
+// meters of c. It's a cheap pre-filter; use Within for the exact check.
+// Boxes that cross the antimeridian are widened to all longitudes.
+func (c Coordinate) BoundingBox(radius float64) BoundingBox {
+	dLat := degrees(radius / earthRadius)
+	minLat, maxLat := math.Max(c.Lat-dLat, -90), math.Min(c.Lat+dLat, 90)
+
+	minLon, maxLon := -180.0, 180.0
+	if cos := math.Cos(radians(c.Lat)); minLat > -90 && maxLat < 90 && cos > 0 {
+		dLon := degrees(radius / (earthRadius * cos))
+		if c.Lon-dLon >= -180 && c.Lon+dLon <= 180 {
+			minLon, maxLon = c.Lon-dLon, c.Lon+dLon
+		}
+	}
+
+	return BoundingBox{
+		Min: Coordinate{Lat: minLat, Lon: minLon},
+		Max: Coordinate{Lat: maxLat, Lon: maxLon},
+	}
+}
+
+// Contains reports whether c lies inside the box.
+func (b BoundingBox) Contains(c Coordinate) bool {
+	return c.Lat >= b.Min.Lat && c.Lat <= b.Max.Lat &&
+		c.Lon >= b.Min.Lon && c.Lon <= b.Max.Lon
+}
+
+// Positioned is anything with a location: tenants, and events via their
+// tenant.
+type Positioned interface {
+	Position() Coordinate
+}
+
+var (
+	_ Positioned = Tenant{}
+	_ Positioned = LessonTenant{}
+	_ Positioned = Class{}
+	_ Positioned = Lesson{}
+	_ Positioned = Match{}
+	_ Positioned = Tournament{}
+)
+
+// Position returns the club's coordinate.
+func (t Tenant) Position() Coordinate { return t.Address.Coordinate }
+
+// Position returns the club's coordinate.
+func (t LessonTenant) Position() Coordinate { return t.TenantAddress.Coordinate }
+
+// Position returns the coordinate of the class's club.
+func (c Class) Position() Coordinate { return c.Tenant.Position() }
+
+// Position returns the coordinate of the lesson's club.
+func (l Lesson) Position() Coordinate { return l.Tenant.Position() }
+
+// Position returns the match location, falling back to the club when the
+// location has no coordinate.
+func (m Match) Position() Coordinate {
+	if c := m.LocationInfo.Address.Coordinate; !c.IsZero() {
+		return c
+	}
+	return m.Tenant.Position()
+}
+
+// Position returns the coordinate of the tournament's club.
+func (t Tournament) Position() Coordinate { return t.Tenant.Position() }
+
+// SortByDistance sorts items nearest first from origin. The sort is stable,
+// so items at the same place keep their order (e.g. by start time).
+func SortByDistance[T Positioned](items []T, origin Coordinate) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return origin.DistanceTo(items[i].Position()) < origin.DistanceTo(items[j].Position())
+	})
+}
+
+// FilterWithinRadius returns the items at most radius meters from origin.
+func FilterWithinRadius[T Positioned](items []T, origin Coordinate, radius float64) []T {
+	var result []T
+	for _, item := range items {
+		if origin.Within(item.Position(), radius) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// GroupByDistance buckets items by distance from origin using ascending
+// limits in meters: group i holds the items within limits[i] but beyond
+// limits[i-1], and a final group holds everything farther than the last
+// limit. Items keep their order within a group.
+func GroupByDistance[T Positioned](items []T, origin Coordinate, limits ...float64) [][]T {
+	groups := make([][]T, len(limits)+1)
+	for _, item := range items {
+		d := origin.DistanceTo(item.Position())
+		i := sort.SearchFloat64s(limits, d)
+		groups[i] = append(groups[i], item)
+	}
+	return groups
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
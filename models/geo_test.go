@@ -0,0 +1,92 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	berlinMitte = Coordinate{Lat: 52.5200, Lon: 13.4050}
+	potsdam     = Coordinate{Lat: 52.3906, Lon: 13.0645}
+	hamburg     = Coordinate{Lat: 53.5511, Lon: 9.9937}
+)
+
+func TestCoordinateDistanceTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Coordinate
+		wantKm float64
+	}{
+		{"same point", berlinMitte, berlinMitte, 0},
+		{"Berlin to Potsdam", berlinMitte, potsdam, 26.9},
+		{"Berlin to Hamburg", berlinMitte, hamburg, 255.3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.a.DistanceTo(tt.b) / 1000
+			if math.Abs(got-tt.wantKm) > 0.5 {
+				t.Errorf("DistanceTo = %.1f km, want about %.1f km", got, tt.wantKm)
+			}
+			if back := tt.b.DistanceTo(tt.a) / 1000; math.Abs(back-got) > 1e-9 {
+				t.Errorf("Distance is not symmetric: %.3f vs %.3f", got, back)
+			}
+		})
+	}
+
+	if !berlinMitte.Within(potsdam, 30000) || berlinMitte.Within(potsdam, 20000) {
+		t.Error("Within disagrees with DistanceTo")
+	}
+}
+
+func TestCoordinateBoundingBox(t *testing.T) {
+	box := berlinMitte.BoundingBox(30000)
+	if !box.Contains(potsdam) {
+		t.Errorf("Expected %+v to contain Potsdam", box)
+	}
+	if box.Contains(hamburg) {
+		t.Errorf("Expected %+v not to contain Hamburg", box)
+	}
+
+	// Near the antimeridian the box spans all longitudes.
+	fiji := Coordinate{Lat: -17.7, Lon: 179.9}
+	if box := fiji.BoundingBox(50000); box.Min.Lon != -180 || box.Max.Lon != 180 {
+		t.Errorf("Expected full longitude range, got %+v", box)
+	}
+}
+
+func tenantAt(id string, c Coordinate) Tenant {
+	return Tenant{TenantID: id, Address: Address{Coordinate: c}}
+}
+
+func TestSortAndGroupByDistance(t *testing.T) {
+	tenants := []Tenant{tenantAt("hamburg", hamburg), tenantAt("potsdam", potsdam), tenantAt("mitte", berlinMitte)}
+
+	SortByDistance(tenants, berlinMitte)
+	if tenants[0].TenantID != "mitte" || tenants[1].TenantID != "potsdam" || tenants[2].TenantID != "hamburg" {
+		t.Errorf("Unexpected order %s, %s, %s", tenants[0].TenantID, tenants[1].TenantID, tenants[2].TenantID)
+	}
+
+	near := FilterWithinRadius(tenants, berlinMitte, 50000)
+	if len(near) != 2 {
+		t.Errorf("Expected 2 tenants within 50 km, got %d", len(near))
+	}
+
+	groups := GroupByDistance(tenants, berlinMitte, 10000, 100000)
+	if len(groups) != 3 || len(groups[0]) != 1 || len(groups[1]) != 1 || len(groups[2]) != 1 {
+		t.Fatalf("Expected one tenant per group, got %v", groups)
+	}
+	if groups[2][0].TenantID != "hamburg" {
+		t.Errorf("Expected Hamburg beyond the last limit, got %s", groups[2][0].TenantID)
+	}
+}
+
+func TestMatchPositionPrefersLocation(t *testing.T) {
+	m := Match{Tenant: tenantAt("club", berlinMitte)}
+	if m.Position() != berlinMitte {
+		t.Errorf("Expected the tenant coordinate, got %+v", m.Position())
+	}
+	m.LocationInfo.Address.Coordinate = potsdam
+	if m.Position() != potsdam {
+		t.Errorf("Expected the location coordinate, got %+v", m.Position())
+	}
+}
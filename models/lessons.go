@@ -4,33 +4,54 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Lesson represents a lesson from the Playtomic API
 type Lesson struct {
-	TournamentID            string         `json:"tournament_id"`
-	TournamentName          string         `json:"tournament_name"`
-	TournamentImage         *string        `json:"tournament_image"`
-	StartDate               string         `json:"start_date"`
-	EndDate                 string         `json:"end_date"`
-	Type                    string         `json:"type"`
-	MinPlayers              int            `json:"min_players"`
-	MaxPlayers              int            `json:"max_players"`
-	RegisteredPlayers       []LessonPlayer `json:"registered_players"`
-	ReservationIDs          interface{}    `json:"reservation_ids"` // Can be null or array
-	LevelDescription        string         `json:"level_description"`
-	Tags                    []string       `json:"tags"`
-	Description             string         `json:"description"`
-	PaymentMethodsAllowed   []string       `json:"payment_methods_allowed"`
-	Price                   string         `json:"price"`
-	SportID                 string         `json:"sport_id"`
-	Gender                  string         `json:"gender"`
-	RegistrationClosingTime string         `json:"registration_closing_time"`
-	IsCancelled             bool           `json:"is_cancelled"`
-	TournamentVisibility    string         `json:"tournament_visibility"`
-	TournamentStatus        string         `json:"tournament_status"`
-	AvailablePlaces         int            `json:"available_places"`
-	Tenant                  LessonTenant   `json:"tenant"`
+	TournamentID            string           `json:"tournament_id"`
+	TournamentName          string           `json:"tournament_name"`
+	TournamentImage         *string          `json:"tournament_image"`
+	StartDate               Time             `json:"start_date"`
+	EndDate                 Time             `json:"end_date"`
+	Type                    string           `json:"type"`
+	MinPlayers              int              `json:"min_players"`
+	MaxPlayers              int              `json:"max_players"`
+	RegisteredPlayers       []LessonPlayer   `json:"registered_players"`
+	ReservationIDs          interface{}      `json:"reservation_ids"` // Can be null or array
+	LevelDescription        string           `json:"level_description"`
+	Tags                    []string         `json:"tags"`
+	Description             string           `json:"description"`
+	PaymentMethodsAllowed   []string         `json:"payment_methods_allowed"`
+	Price                   Money            `json:"price"`
+	SportID                 SportID          `json:"sport_id"`
+	Gender                  Gender           `json:"gender"`
+	RegistrationClosingTime Time             `json:"registration_closing_time"`
+	IsCancelled             bool             `json:"is_cancelled"`
+	TournamentVisibility    Visibility       `json:"tournament_visibility"`
+	TournamentStatus        TournamentStatus `json:"tournament_status"`
+	AvailablePlaces         int              `json:"available_places"`
+	Tenant                  LessonTenant     `json:"tenant"`
+	Coaches                 []Coach          `json:"coaches,omitempty"` // Only set when the lesson has coaches assigned
+}
+
+// Start returns the lesson start time in the tenant's time zone.
+func (l Lesson) Start() time.Time {
+	return l.StartDate.In(l.Tenant.TenantAddress.Location())
+}
+
+// End returns the lesson end time in the tenant's time zone.
+func (l Lesson) End() time.Time {
+	return l.EndDate.In(l.Tenant.TenantAddress.Location())
+}
+
+// RegistrationCloses returns the registration closing time in the tenant's
+// time zone, or the zero time if the lesson has none.
+func (l Lesson) RegistrationCloses() time.Time {
+	if l.RegistrationClosingTime.IsZero() {
+		return time.Time{}
+	}
+	return l.RegistrationClosingTime.In(l.Tenant.TenantAddress.Location())
 }
 
 // LessonPlayer represents a player registered for a lesson
@@ -48,19 +69,19 @@ type LessonPlayer struct {
 
 // LessonTenant represents a club in the lesson context
 type LessonTenant struct {
-	TenantID      string                 `json:"tenant_id"`
-	TenantName    string                 `json:"tenant_name"`
-	TenantAddress Address                `json:"tenant_address"`
-	TenantImages  []string               `json:"tenant_images"`
-	Properties    map[string]interface{} `json:"properties"`
+	TenantID      string     `json:"tenant_id"`
+	TenantName    string     `json:"tenant_name"`
+	TenantAddress Address    `json:"tenant_address"`
+	TenantImages  []string   `json:"tenant_images"`
+	Properties    Properties `json:"properties"`
 }
 
 // SearchLessonsParams defines parameters for searching lessons
 type SearchLessonsParams struct {
 	Sort                 string
 	TenantID             string // Only accepts a single tenant ID, not a list
-	TournamentVisibility string
-	Status               string
+	TournamentVisibility Visibility
+	Status               []TournamentStatus
 	Size                 int
 	Page                 int
 	FromStartDate        string
@@ -78,11 +99,11 @@ func (p *SearchLessonsParams) ToURLValues() url.Values {
 		values.Set("tenant_id", id)
 	}
 
-	if v := strings.TrimSpace(p.TournamentVisibility); v != "" {
+	if v := strings.TrimSpace(string(p.TournamentVisibility)); v != "" {
 		values.Set("tournament_visibility", v)
 	}
 
-	if s := strings.TrimSpace(p.Status); s != "" {
+	if s := joinEnums(p.Status); s != "" {
 		values.Set("status", s)
 	}
 
@@ -24,7 +24,7 @@ func TestSearchLessonsParamsToURLValues(t *testing.T) {
 				Sort:                 "start_date,ASC",
 				TenantID:             "tenant-123",
 				TournamentVisibility: "PUBLIC",
-				Status:               "REGISTRATION_OPEN",
+				Status:               []TournamentStatus{TournamentStatusRegistrationOpen},
 				Size:                 50,
 				Page:                 2,
 				FromStartDate:        "2023-01-01T00:00:00",
@@ -45,7 +45,7 @@ func TestSearchLessonsParamsToURLValues(t *testing.T) {
 				Sort:                 "  start_date,ASC  ",
 				TenantID:             "  tenant-123  ",
 				TournamentVisibility: "  PUBLIC  ",
-				Status:               "  REGISTRATION_OPEN  ",
+				Status:               []TournamentStatus{"  REGISTRATION_OPEN  "},
 			},
 			expected: url.Values{
 				"sort":                  []string{"start_date,ASC"},
@@ -2,8 +2,10 @@ package models
 
 import (
 	"fmt"
+	"math"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Match represents a match from the Playtomic API
@@ -12,34 +14,120 @@ type Match struct {
 	ReservationID                 *string            `json:"reservation_id"`
 	RecurringMatchConfigurationID *string            `json:"recurring_match_configuration_id"`
 	Location                      string             `json:"location"`
-	SportID                       string             `json:"sport_id"`
+	SportID                       SportID            `json:"sport_id"`
 	Teams                         []Team             `json:"teams"`
 	MinPlayersPerTeam             int                `json:"min_players_per_team"`
 	MaxPlayersPerTeam             int                `json:"max_players_per_team"`
 	OwnerID                       *string            `json:"owner_id"`
 	Status                        string             `json:"status"`
 	GameStatus                    string             `json:"game_status"`
-	StartDate                     string             `json:"start_date"`
-	EndDate                       string             `json:"end_date"`
+	StartDate                     Time               `json:"start_date"`
+	EndDate                       Time               `json:"end_date"`
 	Tenant                        Tenant             `json:"tenant"`
 	LocationInfo                  LocationInfo       `json:"location_info"`
-	MatchType                     string             `json:"match_type"`
+	MatchType                     MatchType          `json:"match_type"`
 	MatchOrganization             string             `json:"match_organization"`
 	CompetitionMode               string             `json:"competition_mode"`
-	Gender                        string             `json:"gender"`
+	Gender                        Gender             `json:"gender"`
 	MaxLevel                      float64            `json:"max_level"`
 	MinLevel                      float64            `json:"min_level"`
-	Price                         string             `json:"price"`
+	Price                         Money              `json:"price"`
 	PaymentRequired               bool               `json:"payment_required"`
 	ResourceProperties            ResourceProperties `json:"resource_properties"`
 	RegistrationInfo              RegistrationInfo   `json:"registration_info"`
 	MatchOrigin                   string             `json:"match_origin"`
 	RegistrationType              string             `json:"registration_type"`
-	RegistrationStatus            string             `json:"registration_status"`
+	RegistrationStatus            RegistrationStatus `json:"registration_status"`
 	IsPremium                     bool               `json:"is_premium"`
 	IsBooked                      bool               `json:"is_booked"`
-	CreatedAt                     string             `json:"created_at"`
-	Visibility                    string             `json:"visibility"`
+	CreatedAt                     Time               `json:"created_at"`
+	Visibility                    Visibility         `json:"visibility"`
+}
+
+// Start returns the match start time in the tenant's time zone.
+func (m Match) Start() time.Time {
+	return m.StartDate.In(m.Tenant.Address.Location())
+}
+
+// End returns the match end time in the tenant's time zone.
+func (m Match) End() time.Time {
+	return m.EndDate.In(m.Tenant.Address.Location())
+}
+
+// FreePositions returns the open positions summed over all teams.
+func (m Match) FreePositions() int {
+	free := 0
+	for _, t := range m.Teams {
+		free += t.FreePositions()
+	}
+	return free
+}
+
+// OpenTeams returns the teams that still have free positions.
+func (m Match) OpenTeams() []Team {
+	var open []Team
+	for _, t := range m.Teams {
+		if t.FreePositions() > 0 {
+			open = append(open, t)
+		}
+	}
+	return open
+}
+
+// FitsLevel reports whether level is within the match's level range. A zero
+// MaxLevel means the range has no upper bound.
+func (m Match) FitsLevel(level float64) bool {
+	if level < m.MinLevel {
+		return false
+	}
+	return m.MaxLevel == 0 || level <= m.MaxLevel
+}
+
+// AverageLevel returns the mean level of every player in the match, or 0
+// when nobody has joined yet.
+func (m Match) AverageLevel() float64 {
+	players := m.Participants()
+	if len(players) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, p := range players {
+		sum += p.LevelValue
+	}
+	return sum / float64(len(players))
+}
+
+// LevelBalance returns the absolute difference between the average levels
+// of the first two teams. It's 0 unless both teams have players.
+func (m Match) LevelBalance() float64 {
+	if len(m.Teams) < 2 || len(m.Teams[0].Players) == 0 || len(m.Teams[1].Players) == 0 {
+		return 0
+	}
+	return math.Abs(m.Teams[0].AverageLevel() - m.Teams[1].AverageLevel())
+}
+
+// CanJoin reports whether p could take a position in the match: registration
+// isn't closed, a position is free, p's level fits the range, p's gender
+// matches a single-gender match, and p isn't already playing. Players with
+// no gender on record can't join single-gender matches.
+func (m Match) CanJoin(p Player) bool {
+	if m.RegistrationStatus == RegistrationStatusClosed || m.FreePositions() == 0 {
+		return false
+	}
+	if !m.FitsLevel(p.LevelValue) {
+		return false
+	}
+	if m.Gender == GenderMale || m.Gender == GenderFemale {
+		if p.Gender == nil || !strings.EqualFold(*p.Gender, string(m.Gender)) {
+			return false
+		}
+	}
+	for _, existing := range m.Participants() {
+		if existing.UserID != "" && existing.UserID == p.UserID {
+			return false
+		}
+	}
+	return true
 }
 
 // LocationInfo represents information about the location of a match
@@ -62,9 +150,9 @@ type ResourceProperties struct {
 type SearchMatchesParams struct {
 	Sort          string
 	HasPlayers    bool
-	SportID       string
+	SportID       SportID
 	TenantIDs     []string
-	Visibility    string
+	Visibility    Visibility
 	FromStartDate string
 	Size          int
 	Page          int
@@ -82,7 +170,7 @@ func (p *SearchMatchesParams) ToURLValues() url.Values {
 		values.Set("has_players", "true")
 	}
 
-	if s := strings.TrimSpace(p.SportID); s != "" {
+	if s := strings.TrimSpace(string(p.SportID)); s != "" {
 		values.Set("sport_id", s)
 	}
 
@@ -90,7 +178,7 @@ func (p *SearchMatchesParams) ToURLValues() url.Values {
 		values.Set("tenant_id", strings.Join(p.TenantIDs, ","))
 	}
 
-	if v := strings.TrimSpace(p.Visibility); v != "" {
+	if v := strings.TrimSpace(string(p.Visibility)); v != "" {
 		values.Set("visibility", v)
 	}
 
@@ -102,9 +190,7 @@ func (p *SearchMatchesParams) ToURLValues() url.Values {
 		values.Set("size", fmt.Sprintf("%d", p.Size))
 	}
 
-	if p.Page > 0 {
-		values.Set("page", fmt.Sprintf("%d", p.Page))
-	}
+	values.Set("page", fmt.Sprintf("%d", p.Page))
 
 	return values
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"net/url"
 	"testing"
 )
@@ -14,7 +15,7 @@ func TestSearchMatchesParamsToURLValues(t *testing.T) {
 		{
 			name:     "Empty params",
 			params:   SearchMatchesParams{},
-			expected: url.Values{},
+			expected: url.Values{"page": []string{"0"}}, // page is always sent, like the other searches
 		},
 		{
 			name: "Complete params",
@@ -46,6 +47,7 @@ func TestSearchMatchesParamsToURLValues(t *testing.T) {
 			},
 			expected: url.Values{
 				"has_players": []string{"true"},
+				"page":        []string{"0"},
 			},
 		},
 		{
@@ -59,6 +61,7 @@ func TestSearchMatchesParamsToURLValues(t *testing.T) {
 				"sort":       []string{"start_date,DESC"},
 				"sport_id":   []string{"PADEL"},
 				"visibility": []string{"VISIBLE"},
+				"page":       []string{"0"},
 			},
 		},
 		{
@@ -68,6 +71,7 @@ func TestSearchMatchesParamsToURLValues(t *testing.T) {
 			},
 			expected: url.Values{
 				"tenant_id": []string{"tenant-123"},
+				"page":      []string{"0"},
 			},
 		},
 	}
@@ -103,3 +107,105 @@ func TestSearchMatchesParamsToURLValues(t *testing.T) {
 		})
 	}
 }
+
+func levelPlayer(id string, level float64, gender string) Player {
+	p := Player{BasePlayer: BasePlayer{UserID: id, LevelValue: level}}
+	if gender != "" {
+		p.Gender = &gender
+	}
+	return p
+}
+
+func halfFullMatch() Match {
+	return Match{
+		MinLevel:           2.0,
+		MaxLevel:           3.5,
+		Gender:             GenderMixed,
+		RegistrationStatus: RegistrationStatusOpen,
+		Teams: []Team{
+			{TeamID: "0", MaxPlayers: 2, Players: []Player{levelPlayer("a", 3.0, "MALE"), levelPlayer("b", 2.0, "FEMALE")}},
+			{TeamID: "1", MaxPlayers: 2, Players: []Player{levelPlayer("c", 3.5, "MALE")}},
+		},
+	}
+}
+
+func TestMatchPositions(t *testing.T) {
+	m := halfFullMatch()
+
+	if got := m.FreePositions(); got != 1 {
+		t.Errorf("Expected 1 free position, got %d", got)
+	}
+	open := m.OpenTeams()
+	if len(open) != 1 || open[0].TeamID != "1" {
+		t.Errorf("Expected team 1 to be the only open team, got %v", open)
+	}
+}
+
+func TestMatchLevels(t *testing.T) {
+	m := halfFullMatch()
+
+	if got := m.AverageLevel(); math.Abs(got-8.5/3) > 1e-9 {
+		t.Errorf("Expected average level %.3f, got %.3f", 8.5/3, got)
+	}
+	// Team 0 averages 2.5, team 1 averages 3.5.
+	if got := m.LevelBalance(); math.Abs(got-1.0) > 1e-9 {
+		t.Errorf("Expected level balance 1.0, got %.3f", got)
+	}
+	if (Match{}).LevelBalance() != 0 || (Match{}).AverageLevel() != 0 {
+		t.Error("Expected zero levels for an empty match")
+	}
+
+	tests := []struct {
+		level float64
+		want  bool
+	}{
+		{1.9, false},
+		{2.0, true},
+		{3.5, true},
+		{3.6, false},
+	}
+	for _, tt := range tests {
+		if got := m.FitsLevel(tt.level); got != tt.want {
+			t.Errorf("FitsLevel(%.1f) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	unbounded := Match{MinLevel: 2.0}
+	if !unbounded.FitsLevel(7.0) {
+		t.Error("Expected zero MaxLevel to mean no upper bound")
+	}
+}
+
+func TestMatchCanJoin(t *testing.T) {
+	closed := halfFullMatch()
+	closed.RegistrationStatus = RegistrationStatusClosed
+
+	full := halfFullMatch()
+	full.Teams[1].Players = append(full.Teams[1].Players, levelPlayer("d", 2.5, "FEMALE"))
+
+	womenOnly := halfFullMatch()
+	womenOnly.Gender = GenderFemale
+
+	tests := []struct {
+		name   string
+		match  Match
+		player Player
+		want   bool
+	}{
+		{"fits", halfFullMatch(), levelPlayer("d", 2.5, "FEMALE"), true},
+		{"registration closed", closed, levelPlayer("d", 2.5, "FEMALE"), false},
+		{"no free position", full, levelPlayer("e", 2.5, "FEMALE"), false},
+		{"level too low", halfFullMatch(), levelPlayer("d", 1.0, "FEMALE"), false},
+		{"already playing", halfFullMatch(), levelPlayer("a", 3.0, "MALE"), false},
+		{"gender matches", womenOnly, levelPlayer("d", 2.5, "female"), true},
+		{"gender mismatch", womenOnly, levelPlayer("d", 2.5, "MALE"), false},
+		{"gender unknown", womenOnly, levelPlayer("d", 2.5, ""), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match.CanJoin(tt.player); got != tt.want {
+				t.Errorf("CanJoin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minorUnitsPerMajor is the number of minor units in one unit of currency.
+// Playtomic only deals in two-decimal currencies (EUR, GBP, SEK, ...).
+const minorUnitsPerMajor = 100
+
+// Money is an exact amount of money, as sent by Playtomic in strings such
+// as "36 EUR" or "12.5 EUR". Amount is in minor units (cents) so arithmetic
+// never rounds. Currency is the ISO 4217 code, and may be empty for the few
+// fields that omit it ("30.00").
+//
+// Money decoded from JSON remembers its original string form and encodes
+// back to it unchanged; use Equal rather than == to compare values. A price
+// string ParseMoney rejects decodes to an unknown Money (see Known) that
+// keeps the text, rather than failing the whole response.
+type Money struct {
+	Amount   int64
+	Currency string
+
+	text    string
+	unknown bool
+}
+
+// NewMoney returns an amount of minor units in currency.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a Playtomic price such as "36 EUR", "12.5 EUR" or
+// "30.00". At most two decimal places are accepted.
+func ParseMoney(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Money{}, fmt.Errorf("invalid price %q", s)
+	}
+
+	var currency string
+	if len(fields) == 2 {
+		currency = fields[1]
+	}
+
+	whole, frac, _ := strings.Cut(fields[0], ".")
+	negative := strings.HasPrefix(whole, "-")
+	whole = strings.TrimPrefix(whole, "-")
+	if whole == "" || !allDigits(whole) || !allDigits(frac) {
+		return Money{}, fmt.Errorf("invalid price %q", s)
+	}
+	if len(frac) > 2 {
+		return Money{}, fmt.Errorf("invalid price %q: more than 2 decimal places", s)
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid price %q: %w", s, err)
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid price %q: %w", s, err)
+	}
+
+	amount := units*minorUnitsPerMajor + cents
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency, text: s}, nil
+}
+
+// allDigits reports whether s has only ASCII digits, "" included.
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MustParseMoney is like ParseMoney but panics if s can't be parsed. It's
+// meant for tests and fixtures with constant inputs.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// IsZero reports whether m is the zero value (no amount and no currency).
+// An unknown Money isn't zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0 && m.Currency == "" && !m.unknown
+}
+
+// Known reports whether m holds an amount. It's false for a price decoded
+// from text ParseMoney couldn't read; String still returns that text, but
+// the amount is meaningless and arithmetic on m fails.
+func (m Money) Known() bool {
+	return !m.unknown
+}
+
+// Decimal formats the amount in major units with two decimals, e.g. "-12.50".
+func (m Money) Decimal() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/minorUnitsPerMajor, amount%minorUnitsPerMajor)
+}
+
+// String returns the original string form when m was parsed, and otherwise
+// Playtomic's form: whole amounts without decimals ("36 EUR"), others with
+// two ("12.50 EUR").
+func (m Money) String() string {
+	if m.text != "" {
+		return m.text
+	}
+
+	s := m.Decimal()
+	if m.Amount%minorUnitsPerMajor == 0 {
+		s = strings.TrimSuffix(s, ".00")
+	}
+	if m.Currency != "" {
+		s += " " + m.Currency
+	}
+	return s
+}
+
+// Equal reports whether m and o are the same amount in the same currency,
+// regardless of their original string form.
+func (m Money) Equal(o Money) bool {
+	return m.Amount == o.Amount && m.Currency == o.Currency
+}
+
+// Compare returns -1, 0 or +1 depending on whether m is less than, equal to
+// or greater than o. Amounts in different currencies can't be compared.
+func (m Money) Compare(o Money) (int, error) {
+	if _, err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Add returns m + o. The zero Money adopts o's currency, so totals can be
+// accumulated from a zero value.
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := m.sameCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: currency}, nil
+}
+
+// Sub returns m - o.
+func (m Money) Sub(o Money) (Money, error) {
+	currency, err := m.sameCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: currency}, nil
+}
+
+// Neg returns -m. An unknown Money stays unknown.
+func (m Money) Neg() Money {
+	if !m.Known() {
+		return m
+	}
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Split divides m into n parts that add back up to m exactly, e.g. a court
+// price shared by 4 players. Leftover minor units go to the first parts, so
+// "10 EUR" split 3 ways is 3.34, 3.33, 3.33.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 || !m.Known() {
+		return nil, fmt.Errorf("cannot split %s into %d parts", m, n)
+	}
+
+	share := m.Amount / int64(n)
+	remainder := m.Amount % int64(n)
+
+	parts := make([]Money, n)
+	for i := range parts {
+		amount := share
+		if remainder > 0 {
+			amount++
+			remainder--
+		} else if remainder < 0 {
+			amount--
+			remainder++
+		}
+		parts[i] = Money{Amount: amount, Currency: m.Currency}
+	}
+	return parts, nil
+}
+
+// SumMoney adds up amounts in a single currency.
+func SumMoney(amounts ...Money) (Money, error) {
+	var total Money
+	for _, m := range amounts {
+		var err error
+		if total, err = total.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// sameCurrency returns the currency shared by m and o. A zero Money is
+// compatible with any currency.
+func (m Money) sameCurrency(o Money) (string, error) {
+	switch {
+	case !m.Known():
+		return "", fmt.Errorf("unknown price %q", m.text)
+	case !o.Known():
+		return "", fmt.Errorf("unknown price %q", o.text)
+	case m.IsZero():
+		return o.Currency, nil
+	case o.IsZero():
+		return m.Currency, nil
+	case m.Currency != o.Currency:
+		return "", fmt.Errorf("currency mismatch: %s and %s", m, o)
+	}
+	return m.Currency, nil
+}
+
+// MarshalJSON implements json.Marshaler. The zero Money encodes as null.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.IsZero() && m.text == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null or "" decode to the zero
+// Money, and a string ParseMoney rejects to an unknown Money keeping it.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteJSON(data)
+	if err != nil || !ok {
+		*m = Money{}
+		return err
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		*m = Money{text: s, unknown: true}
+		return nil
+	}
+	*m = parsed
+	return nil
+}
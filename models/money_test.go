@@ -0,0 +1,162 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input    string
+		amount   int64
+		currency string
+		wantErr  bool
+	}{
+		{input: "36 EUR", amount: 3600, currency: "EUR"},
+		{input: "12.5 EUR", amount: 1250, currency: "EUR"},
+		{input: "9.99 GBP", amount: 999, currency: "GBP"},
+		{input: "30.00", amount: 3000},
+		{input: "-4.20 EUR", amount: -420, currency: "EUR"},
+		{input: "", wantErr: true},
+		{input: "free", wantErr: true},
+		{input: "1.234 EUR", wantErr: true},
+		{input: "1.-5 EUR", wantErr: true},
+		{input: "1.+5 EUR", wantErr: true},
+		{input: "1.5x EUR", wantErr: true},
+		{input: "1. 5 EUR", wantErr: true},
+		{input: "+1.50 EUR", wantErr: true},
+		{input: "--1.50 EUR", wantErr: true},
+		{input: "-.50 EUR", wantErr: true},
+		{input: "1.٥ EUR", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m, err := ParseMoney(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got none", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if m.Amount != tt.amount || m.Currency != tt.currency {
+				t.Errorf("Expected %d %s, got %d %s", tt.amount, tt.currency, m.Amount, m.Currency)
+			}
+			if m.String() != tt.input {
+				t.Errorf("Expected original form %q, got %q", tt.input, m.String())
+			}
+		})
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	data := []byte(`[{"start_time":"17:00:00","duration":90,"price":"36 EUR"},{"start_time":"18:30:00","duration":60,"price":"24.5 EUR"},{"start_time":"20:00:00","duration":60,"price":null}]`)
+
+	var slots []Slot
+	if err := json.Unmarshal(data, &slots); err != nil {
+		t.Fatalf("Expected successful decoding, got error: %v", err)
+	}
+	if slots[1].Price.Amount != 2450 {
+		t.Errorf("Expected 2450 minor units, got %d", slots[1].Price.Amount)
+	}
+	if !slots[2].Price.IsZero() {
+		t.Errorf("Expected null price to decode to zero Money, got %s", slots[2].Price)
+	}
+
+	out, err := json.Marshal(slots)
+	if err != nil {
+		t.Fatalf("Expected successful encoding, got error: %v", err)
+	}
+	if string(out) != string(data) {
+		t.Errorf("Expected round trip to %s, got %s", data, out)
+	}
+
+	// A price that can't be parsed keeps its text instead of failing the
+	// whole decode.
+	if err := json.Unmarshal([]byte(`{"price":"lots"}`), &slots[0]); err != nil {
+		t.Fatalf("Expected unparseable price to decode, got error: %v", err)
+	}
+	price := slots[0].Price
+	if price.Known() || price.IsZero() || price.String() != "lots" {
+		t.Errorf("Expected an unknown price keeping its text, got %q (known %v)", price, price.Known())
+	}
+	if _, err := price.Add(MustParseMoney("1 EUR")); err == nil {
+		t.Errorf("Expected error adding to an unknown price, got none")
+	}
+	if out, err := json.Marshal(price); err != nil || string(out) != `"lots"` {
+		t.Errorf("Expected unknown price to encode as \"lots\", got %s (%v)", out, err)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := MustParseMoney("36 EUR")
+	b := MustParseMoney("12.5 EUR")
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sum.String() != "48.50 EUR" {
+		t.Errorf("Expected 48.50 EUR, got %s", sum)
+	}
+
+	diff, err := a.Sub(b)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !diff.Equal(NewMoney(2350, "EUR")) {
+		t.Errorf("Expected 23.50 EUR, got %s", diff)
+	}
+
+	if cmp, err := b.Compare(a); err != nil || cmp != -1 {
+		t.Errorf("Expected 12.5 EUR < 36 EUR, got %d (%v)", cmp, err)
+	}
+
+	if _, err := a.Add(MustParseMoney("10 GBP")); err == nil {
+		t.Errorf("Expected currency mismatch error, got none")
+	}
+	if _, err := a.Compare(MustParseMoney("10 GBP")); err == nil {
+		t.Errorf("Expected currency mismatch error, got none")
+	}
+
+	total, err := SumMoney(a, b, b.Neg())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !total.Equal(a) {
+		t.Errorf("Expected total 36 EUR, got %s", total)
+	}
+}
+
+func TestMoneySplit(t *testing.T) {
+	parts, err := MustParseMoney("36 EUR").Split(4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, p := range parts {
+		if p.String() != "9 EUR" {
+			t.Errorf("Expected 9 EUR per player, got %s", p)
+		}
+	}
+
+	parts, err = MustParseMoney("10 EUR").Split(3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []int64{334, 333, 333}
+	for i, p := range parts {
+		if p.Amount != expected[i] {
+			t.Errorf("Part %d: expected %d, got %d", i, expected[i], p.Amount)
+		}
+	}
+	if total, _ := SumMoney(parts...); total.Amount != 1000 {
+		t.Errorf("Expected parts to add back up to 1000, got %d", total.Amount)
+	}
+
+	if _, err := MustParseMoney("10 EUR").Split(0); err == nil {
+		t.Errorf("Expected error splitting into 0 parts, got none")
+	}
+}
@@ -1,5 +1,12 @@
 package models
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
 // Payment represents payment information
 type Payment struct {
 	PaymentID               string  `json:"payment_id"`
@@ -11,8 +18,76 @@ type Payment struct {
 	TenantVat               float64 `json:"tenant_vat"`
 	CommissionModel         string  `json:"commission_model"`
 	RefundID                *string `json:"refund_id"`
-	PaymentPrice            string  `json:"payment_price"`
+	PaymentPrice            Money   `json:"payment_price"`
 	PaymentReference        *string `json:"payment_reference"`
 	PayerID                 string  `json:"payer_id"`
-	PaymentDate             string  `json:"payment_date"`
+	PaymentDate             Time    `json:"payment_date"`
+	ReservationID           *string `json:"reservation_id,omitempty"`   // Only set when listing payments directly
+	AcademyClassID          *string `json:"academy_class_id,omitempty"` // Only set when listing payments directly
+	TenantID                string  `json:"tenant_id,omitempty"`        // Only set when listing payments directly
+}
+
+// IsRefund reports whether the payment is a refund of an earlier payment.
+func (p Payment) IsRefund() bool {
+	return p.RefundID != nil && *p.RefundID != ""
+}
+
+// Amount returns PaymentPrice, negated for refunds so a list of payments
+// can be summed directly with SumMoney.
+func (p Payment) Amount() Money {
+	if p.IsRefund() && p.PaymentPrice.Amount > 0 {
+		return p.PaymentPrice.Neg()
+	}
+	return p.PaymentPrice
+}
+
+// FilterPaymentsByDate returns the payments whose PaymentDate falls within
+// [from, to). A zero from or to leaves that side of the range open.
+// Payments without a date are dropped.
+func FilterPaymentsByDate(payments []Payment, from, to time.Time) []Payment {
+	var result []Payment
+	for _, p := range payments {
+		if p.PaymentDate.IsZero() {
+			continue
+		}
+		if !from.IsZero() && p.PaymentDate.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !p.PaymentDate.Before(to) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// SearchPaymentsParams defines parameters for listing the caller's payments
+type SearchPaymentsParams struct {
+	UserID string // Defaults to "me", the authenticated user
+	Sort   string
+	Size   int
+	Page   int
+}
+
+// ToURLValues converts SearchPaymentsParams to url.Values
+func (p *SearchPaymentsParams) ToURLValues() url.Values {
+	values := url.Values{}
+
+	userID := strings.TrimSpace(p.UserID)
+	if userID == "" {
+		userID = "me"
+	}
+	values.Set("user_id", userID)
+
+	if s := strings.TrimSpace(p.Sort); s != "" {
+		values.Set("sort", s)
+	}
+
+	if p.Size > 0 {
+		values.Set("size", fmt.Sprintf("%d", p.Size))
+	}
+
+	values.Set("page", fmt.Sprintf("%d", p.Page))
+
+	return values
 }
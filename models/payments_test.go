@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPaymentAmountRefund(t *testing.T) {
+	refundID := "refund-1"
+	p := Payment{PaymentPrice: MustParseMoney("18 EUR"), RefundID: &refundID}
+
+	amount := p.Amount()
+	if !amount.Equal(NewMoney(-1800, "EUR")) {
+		t.Errorf("Expected -18 EUR for a refund, got %s", amount)
+	}
+}
+
+func TestFilterPaymentsByDate(t *testing.T) {
+	payments := []Payment{
+		{PaymentID: "1", PaymentDate: MustParseTime("2026-02-28T23:59:59")},
+		{PaymentID: "2", PaymentDate: MustParseTime("2026-03-01T00:00:00")},
+		{PaymentID: "3", PaymentDate: MustParseTime("2026-03-31T18:00:00")},
+		{PaymentID: "4", PaymentDate: MustParseTime("2026-04-01T00:00:00")},
+		{PaymentID: "5"},
+	}
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	result := FilterPaymentsByDate(payments, from, to)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 payments, got %d", len(result))
+	}
+	if result[0].PaymentID != "2" || result[1].PaymentID != "3" {
+		t.Errorf("Expected payments 2 and 3, got %s and %s", result[0].PaymentID, result[1].PaymentID)
+	}
+
+	if open := FilterPaymentsByDate(payments, time.Time{}, time.Time{}); len(open) != 4 {
+		t.Errorf("Expected 4 payments with an open range, got %d", len(open))
+	}
+}
+
+func TestSearchPaymentsParamsToURLValues(t *testing.T) {
+	values := (&SearchPaymentsParams{}).ToURLValues()
+	if values.Get("user_id") != "me" {
+		t.Errorf("Expected user_id to default to 'me', got %q", values.Get("user_id"))
+	}
+	if values.Get("page") != "0" {
+		t.Errorf("Expected page '0', got %q", values.Get("page"))
+	}
+	if values.Has("size") {
+		t.Errorf("Expected no size when unset, got %q", values.Get("size"))
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Properties holds the free-form "properties" object the API attaches to
+// tenants and resources. Values are kept exactly as decoded; the typed
+// accessors below convert on read, and Decode maps the whole object onto a
+// struct. Indexing the map directly remains the escape hatch for keys none
+// of the typed views know about.
+type Properties map[string]interface{}
+
+// GetString returns the value of key if it's a string.
+func (p Properties) GetString(key string) (string, bool) {
+	s, ok := p[key].(string)
+	return s, ok
+}
+
+// GetBool returns the value of key if it's a boolean. The strings "true"
+// and "false" are accepted too, since the API isn't consistent about it.
+func (p Properties) GetBool(key string) (bool, bool) {
+	switch v := p[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// GetFloat returns the value of key if it's a number.
+func (p Properties) GetFloat(key string) (float64, bool) {
+	f, ok := p[key].(float64)
+	return f, ok
+}
+
+// Decode maps the properties onto v, which must be a pointer to a struct
+// with json tags. Keys v doesn't declare are ignored.
+func (p Properties) Decode(v interface{}) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding properties: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding properties: %w", err)
+	}
+	return nil
+}
+
+// Known values of the ResourceProperties fields.
+const (
+	ResourceTypeIndoor        = "indoor"
+	ResourceTypeOutdoor       = "outdoor"
+	ResourceTypeRoofedOutdoor = "roofed_outdoor"
+	ResourceSizeSingle        = "single"
+	ResourceSizeDouble        = "double"
+	ResourceFeaturePanoramic  = "panoramic"
+)
+
+// IsIndoor reports whether the court is fully indoor. Roofed outdoor courts
+// don't count.
+func (rp ResourceProperties) IsIndoor() bool {
+	return strings.EqualFold(rp.ResourceType, ResourceTypeIndoor)
+}
+
+// IsDouble reports whether the court is for doubles.
+func (rp ResourceProperties) IsDouble() bool {
+	return strings.EqualFold(rp.ResourceSize, ResourceSizeDouble)
+}
+
+// IsPanoramic reports whether the court has panoramic (glass) walls.
+func (rp ResourceProperties) IsPanoramic() bool {
+	return strings.EqualFold(rp.ResourceFeature, ResourceFeaturePanoramic)
+}
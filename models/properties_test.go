@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPropertiesAccessors(t *testing.T) {
+	var tenant Tenant
+	data := `{"tenant_id": "t1", "properties": {"has_parking": true, "wifi": "false", "courts": 6, "note": "ring the bell"}}`
+	if err := json.Unmarshal([]byte(data), &tenant); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	p := tenant.Properties
+
+	if v, ok := p.GetBool("has_parking"); !ok || !v {
+		t.Errorf("GetBool(has_parking) = %v, %v", v, ok)
+	}
+	if v, ok := p.GetBool("wifi"); !ok || v {
+		t.Errorf("GetBool(wifi) = %v, %v; want false, true", v, ok)
+	}
+	if v, ok := p.GetFloat("courts"); !ok || v != 6 {
+		t.Errorf("GetFloat(courts) = %v, %v", v, ok)
+	}
+	if v, ok := p.GetString("note"); !ok || v != "ring the bell" {
+		t.Errorf("GetString(note) = %q, %v", v, ok)
+	}
+	if _, ok := p.GetString("courts"); ok {
+		t.Error("Expected GetString on a number to fail")
+	}
+	if _, ok := p.GetBool("missing"); ok {
+		t.Error("Expected GetBool on a missing key to fail")
+	}
+
+	// The raw map stays available for keys without an accessor.
+	if p["note"] != "ring the bell" {
+		t.Errorf("Expected raw access to work, got %v", p["note"])
+	}
+
+	var decoded struct {
+		HasParking bool    `json:"has_parking"`
+		Courts     float64 `json:"courts"`
+	}
+	if err := p.Decode(&decoded); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !decoded.HasParking || decoded.Courts != 6 {
+		t.Errorf("Decode gave %+v", decoded)
+	}
+}
+
+func TestResourceProperties(t *testing.T) {
+	r := Resource{Properties: Properties{
+		"resource_type":    "indoor",
+		"resource_size":    "double",
+		"resource_feature": "panoramic",
+		"lights":           true,
+	}}
+
+	rp := r.ResourceProperties()
+	if !rp.IsIndoor() || !rp.IsDouble() || !rp.IsPanoramic() {
+		t.Errorf("Expected indoor double panoramic, got %+v", rp)
+	}
+
+	outdoor := Resource{Properties: Properties{"resource_type": "roofed_outdoor", "resource_size": "single", "resource_size_x": 1}}
+	rp = outdoor.ResourceProperties()
+	if rp.IsIndoor() || rp.IsDouble() || rp.IsPanoramic() {
+		t.Errorf("Expected roofed outdoor single court, got %+v", rp)
+	}
+
+	if (Resource{}).ResourceProperties() != (ResourceProperties{}) {
+		t.Error("Expected empty properties for a resource without any")
+	}
+
+	m := Match{ResourceProperties: ResourceProperties{ResourceType: "INDOOR"}}
+	if !m.ResourceProperties.IsIndoor() {
+		t.Error("Expected case-insensitive match on resource type")
+	}
+}
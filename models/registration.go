@@ -4,7 +4,7 @@ package models
 type RegistrationInfo struct {
 	PaymentType          string         `json:"payment_type"`
 	NumberOfPlayers      int            `json:"number_of_players"`
-	BasePrice            string         `json:"base_price"`
+	BasePrice            Money          `json:"base_price"`
 	IsManualPrice        bool           `json:"is_manual_price"`
 	Registrations        []Registration `json:"registrations"`
 	OnlinePaymentAllowed bool           `json:"online_payment_allowed"`
@@ -15,7 +15,7 @@ type Registration struct {
 	ClassRegistrationID      string      `json:"class_registration_id"`
 	Player                   Player      `json:"player"`
 	Price                    string      `json:"price"`
-	RegistrationDate         string      `json:"registration_date"`
+	RegistrationDate         Time        `json:"registration_date"`
 	Payment                  Payment     `json:"payment"`
 	CustomPriceConfiguration interface{} `json:"custom_price_configuration"`
 	CustomPrice              string      `json:"custom_price"`
@@ -2,8 +2,24 @@ package models
 
 // Resource represents a court or other resource
 type Resource struct {
-	ID         string                 `json:"id"`
-	LockID     string                 `json:"lock_id"`
-	Name       string                 `json:"name"`
-	Properties map[string]interface{} `json:"properties"`
+	ID         string     `json:"id"`
+	LockID     string     `json:"lock_id"`
+	Name       string     `json:"name"`
+	Properties Properties `json:"properties"`
+}
+
+// ResourceProperties reads the resource's properties into the typed view
+// also used by Match. It's read on each call; keys missing or of an
+// unexpected type are left empty.
+func (r Resource) ResourceProperties() ResourceProperties {
+	return ResourceProperties{
+		ResourceType:    stringProperty(r.Properties, "resource_type"),
+		ResourceSize:    stringProperty(r.Properties, "resource_size"),
+		ResourceFeature: stringProperty(r.Properties, "resource_feature"),
+	}
+}
+
+func stringProperty(p Properties, key string) string {
+	s, _ := p.GetString(key)
+	return s
 }
@@ -0,0 +1,411 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxAvailabilityWindow is the longest start_min..start_max range the
+// v1/availability endpoint accepts.
+const MaxAvailabilityWindow = 25 * time.Hour
+
+// The builders below assemble Search*Params from typed values - time.Time
+// instead of hand-formatted strings, enums instead of free text - and check
+// the combinations the API would otherwise silently ignore or reject. Setter
+// calls chain; Build reports every problem at once, before any request is
+// made:
+//
+//	params, err := models.NewClassSearch().
+//		Tenants("tenant-1").
+//		From(time.Now()).
+//		Status(models.ClassStatusPending).
+//		Build()
+
+// ClassSearch builds SearchClassesParams.
+type ClassSearch struct {
+	params SearchClassesParams
+}
+
+// NewClassSearch starts a class search with the maximum page size.
+func NewClassSearch() *ClassSearch {
+	return &ClassSearch{params: SearchClassesParams{Size: MaxClassesPageSize}}
+}
+
+// Tenants restricts the search to the given clubs.
+func (b *ClassSearch) Tenants(ids ...string) *ClassSearch {
+	b.params.TenantIDs = append(b.params.TenantIDs, ids...)
+	return b
+}
+
+// Near searches around c within radius meters. It can't be combined with
+// Tenants.
+func (b *ClassSearch) Near(c Coordinate, radius int) *ClassSearch {
+	b.params.Coordinate = &c
+	b.params.Radius = radius
+	return b
+}
+
+// From only returns classes starting at or after t.
+func (b *ClassSearch) From(t time.Time) *ClassSearch {
+	b.params.FromStartDate = FormatTimeUTC(t)
+	return b
+}
+
+// Status restricts the search to the given statuses.
+func (b *ClassSearch) Status(s ...ClassStatus) *ClassSearch {
+	b.params.Status = append(b.params.Status, s...)
+	return b
+}
+
+// Type restricts the search to the given class types.
+func (b *ClassSearch) Type(t ...ClassType) *ClassSearch {
+	b.params.Type = append(b.params.Type, t...)
+	return b
+}
+
+// IncludeSummary asks for the course summary of each class.
+func (b *ClassSearch) IncludeSummary() *ClassSearch {
+	b.params.IncludeSummary = true
+	return b
+}
+
+// Sort sets the sort expression, e.g. "start_date,ASC".
+func (b *ClassSearch) Sort(s string) *ClassSearch {
+	b.params.Sort = s
+	return b
+}
+
+// Page sets the zero-based page number.
+func (b *ClassSearch) Page(n int) *ClassSearch {
+	b.params.Page = n
+	return b
+}
+
+// PageSize sets the page size, at most MaxClassesPageSize.
+func (b *ClassSearch) PageSize(n int) *ClassSearch {
+	b.params.Size = n
+	return b
+}
+
+// Build validates the search and returns its parameters.
+func (b *ClassSearch) Build() (*SearchClassesParams, error) {
+	var errs []error
+	if b.params.Coordinate != nil {
+		if len(b.params.TenantIDs) > 0 {
+			errs = append(errs, errors.New("coordinate and tenants can't be combined; the API ignores the coordinate"))
+		}
+		errs = append(errs, validateCoordinate(*b.params.Coordinate)...)
+		if b.params.Radius <= 0 {
+			errs = append(errs, fmt.Errorf("radius must be positive, got %d", b.params.Radius))
+		}
+	}
+	errs = append(errs, validatePaging(b.params.Page, b.params.Size, MaxClassesPageSize)...)
+	errs = append(errs, validateEnums("status", b.params.Status)...)
+	errs = append(errs, validateEnums("type", b.params.Type)...)
+	if err := searchError("class search", errs); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	return &params, nil
+}
+
+// MatchSearch builds SearchMatchesParams.
+type MatchSearch struct {
+	params SearchMatchesParams
+}
+
+// NewMatchSearch starts a match search.
+func NewMatchSearch() *MatchSearch {
+	return &MatchSearch{}
+}
+
+// Tenants restricts the search to the given clubs.
+func (b *MatchSearch) Tenants(ids ...string) *MatchSearch {
+	b.params.TenantIDs = append(b.params.TenantIDs, ids...)
+	return b
+}
+
+// Sport restricts the search to one sport.
+func (b *MatchSearch) Sport(s SportID) *MatchSearch {
+	b.params.SportID = s
+	return b
+}
+
+// Visibility restricts the search to one visibility.
+func (b *MatchSearch) Visibility(v Visibility) *MatchSearch {
+	b.params.Visibility = v
+	return b
+}
+
+// HasPlayers only returns matches that already have players.
+func (b *MatchSearch) HasPlayers() *MatchSearch {
+	b.params.HasPlayers = true
+	return b
+}
+
+// From only returns matches starting at or after t.
+func (b *MatchSearch) From(t time.Time) *MatchSearch {
+	b.params.FromStartDate = FormatTimeUTC(t)
+	return b
+}
+
+// Sort sets the sort expression, e.g. "start_date,ASC".
+func (b *MatchSearch) Sort(s string) *MatchSearch {
+	b.params.Sort = s
+	return b
+}
+
+// Page sets the zero-based page number.
+func (b *MatchSearch) Page(n int) *MatchSearch {
+	b.params.Page = n
+	return b
+}
+
+// PageSize sets the page size.
+func (b *MatchSearch) PageSize(n int) *MatchSearch {
+	b.params.Size = n
+	return b
+}
+
+// Build validates the search and returns its parameters.
+func (b *MatchSearch) Build() (*SearchMatchesParams, error) {
+	errs := validatePaging(b.params.Page, b.params.Size, 0)
+	errs = append(errs, validateEnum("sport", b.params.SportID)...)
+	errs = append(errs, validateEnum("visibility", b.params.Visibility)...)
+	if err := searchError("match search", errs); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	return &params, nil
+}
+
+// LessonSearch builds SearchLessonsParams.
+type LessonSearch struct {
+	params SearchLessonsParams
+}
+
+// NewLessonSearch starts a lesson search.
+func NewLessonSearch() *LessonSearch {
+	return &LessonSearch{}
+}
+
+// Tenant restricts the search to one club; the endpoint accepts only one.
+func (b *LessonSearch) Tenant(id string) *LessonSearch {
+	b.params.TenantID = id
+	return b
+}
+
+// Visibility restricts the search to one tournament visibility.
+func (b *LessonSearch) Visibility(v Visibility) *LessonSearch {
+	b.params.TournamentVisibility = v
+	return b
+}
+
+// Status restricts the search to the given statuses.
+func (b *LessonSearch) Status(s ...TournamentStatus) *LessonSearch {
+	b.params.Status = append(b.params.Status, s...)
+	return b
+}
+
+// From only returns lessons starting at or after t.
+func (b *LessonSearch) From(t time.Time) *LessonSearch {
+	b.params.FromStartDate = FormatTimeUTC(t)
+	return b
+}
+
+// Sort sets the sort expression, e.g. "start_date,ASC".
+func (b *LessonSearch) Sort(s string) *LessonSearch {
+	b.params.Sort = s
+	return b
+}
+
+// Page sets the zero-based page number.
+func (b *LessonSearch) Page(n int) *LessonSearch {
+	b.params.Page = n
+	return b
+}
+
+// PageSize sets the page size.
+func (b *LessonSearch) PageSize(n int) *LessonSearch {
+	b.params.Size = n
+	return b
+}
+
+// Build validates the search and returns its parameters.
+func (b *LessonSearch) Build() (*SearchLessonsParams, error) {
+	errs := validatePaging(b.params.Page, b.params.Size, 0)
+	errs = append(errs, validateEnum("visibility", b.params.TournamentVisibility)...)
+	errs = append(errs, validateEnums("status", b.params.Status)...)
+	if err := searchError("lesson search", errs); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	return &params, nil
+}
+
+// TournamentSearch builds SearchTournamentsParams.
+type TournamentSearch struct {
+	params SearchTournamentsParams
+}
+
+// NewTournamentSearch starts a tournament search.
+func NewTournamentSearch() *TournamentSearch {
+	return &TournamentSearch{}
+}
+
+// Tenant restricts the search to one club.
+func (b *TournamentSearch) Tenant(id string) *TournamentSearch {
+	b.params.TenantID = id
+	return b
+}
+
+// AvailablePlaces only returns tournaments with free places.
+func (b *TournamentSearch) AvailablePlaces() *TournamentSearch {
+	b.params.AvailablePlaces = true
+	return b
+}
+
+// RegistrationStatus restricts the search to one registration status.
+func (b *TournamentSearch) RegistrationStatus(s RegistrationStatus) *TournamentSearch {
+	b.params.RegistrationStatus = s
+	return b
+}
+
+// Status restricts the search to the given statuses.
+func (b *TournamentSearch) Status(s ...TournamentStatus) *TournamentSearch {
+	b.params.Status = append(b.params.Status, s...)
+	return b
+}
+
+// Visibility restricts the search to one visibility.
+func (b *TournamentSearch) Visibility(v Visibility) *TournamentSearch {
+	b.params.Visibility = v
+	return b
+}
+
+// Build validates the search and returns its parameters.
+func (b *TournamentSearch) Build() (*SearchTournamentsParams, error) {
+	errs := validateEnum("registration status", b.params.RegistrationStatus)
+	errs = append(errs, validateEnums("status", b.params.Status)...)
+	errs = append(errs, validateEnum("visibility", b.params.Visibility)...)
+	if err := searchError("tournament search", errs); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	return &params, nil
+}
+
+// AvailabilitySearch builds SearchAvailabilityParams.
+type AvailabilitySearch struct {
+	params     SearchAvailabilityParams
+	start, end time.Time
+}
+
+// NewAvailabilitySearch starts a court availability search for one club.
+// The sport defaults to padel.
+func NewAvailabilitySearch(tenantID string) *AvailabilitySearch {
+	return &AvailabilitySearch{params: SearchAvailabilityParams{TenantID: tenantID, SportID: SportPadel}}
+}
+
+// Sport sets the sport.
+func (b *AvailabilitySearch) Sport(s SportID) *AvailabilitySearch {
+	b.params.SportID = s
+	return b
+}
+
+// Window returns slots starting in [start, end]. It must not be longer than
+// MaxAvailabilityWindow.
+func (b *AvailabilitySearch) Window(start, end time.Time) *AvailabilitySearch {
+	b.start, b.end = start, end
+	return b
+}
+
+// Day returns the slots starting on the calendar day of d in loc, e.g.
+// midnight to 23:59:59 in the club's time zone.
+func (b *AvailabilitySearch) Day(d time.Time, loc *time.Location) *AvailabilitySearch {
+	y, m, day := d.In(loc).Date()
+	start := time.Date(y, m, day, 0, 0, 0, 0, loc)
+	return b.Window(start, start.AddDate(0, 0, 1).Add(-time.Second))
+}
+
+// Build validates the search and returns its parameters.
+func (b *AvailabilitySearch) Build() (*SearchAvailabilityParams, error) {
+	var errs []error
+	if b.params.TenantID == "" {
+		errs = append(errs, errors.New("tenant is required"))
+	}
+	if b.params.SportID == "" {
+		errs = append(errs, errors.New("sport is required"))
+	}
+	errs = append(errs, validateEnum("sport", b.params.SportID)...)
+	switch {
+	case b.start.IsZero() || b.end.IsZero():
+		errs = append(errs, errors.New("window is required"))
+	case !b.end.After(b.start):
+		errs = append(errs, fmt.Errorf("window end %s is not after start %s", FormatTimeUTC(b.end), FormatTimeUTC(b.start)))
+	case b.end.Sub(b.start) > MaxAvailabilityWindow:
+		errs = append(errs, fmt.Errorf("window of %s exceeds the maximum of %s", b.end.Sub(b.start), MaxAvailabilityWindow))
+	}
+	if err := searchError("availability search", errs); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	params.StartMin = FormatTimeUTC(b.start)
+	params.StartMax = FormatTimeUTC(b.end)
+	return &params, nil
+}
+
+// validatePaging checks page and size. A zero size leaves it to the API;
+// max <= 0 means size has no upper bound.
+func validatePaging(page, size, max int) []error {
+	var errs []error
+	if page < 0 {
+		errs = append(errs, fmt.Errorf("page must not be negative, got %d", page))
+	}
+	if size < 0 {
+		errs = append(errs, fmt.Errorf("page size must not be negative, got %d", size))
+	} else if max > 0 && size > max {
+		errs = append(errs, fmt.Errorf("page size %d exceeds the maximum of %d", size, max))
+	}
+	return errs
+}
+
+func validateCoordinate(c Coordinate) []error {
+	var errs []error
+	if c.Lat < -90 || c.Lat > 90 {
+		errs = append(errs, fmt.Errorf("latitude %v is out of range [-90, 90]", c.Lat))
+	}
+	if c.Lon < -180 || c.Lon > 180 {
+		errs = append(errs, fmt.Errorf("longitude %v is out of range [-180, 180]", c.Lon))
+	}
+	return errs
+}
+
+// validateEnum checks v against its known values; empty means unset.
+func validateEnum[T Enum[T]](name string, v T) []error {
+	if v == "" || v.Valid() {
+		return nil
+	}
+	return []error{fmt.Errorf("%s: %w", name, unknownEnumError(v))}
+}
+
+func validateEnums[T Enum[T]](name string, vs []T) []error {
+	var errs []error
+	for _, v := range vs {
+		errs = append(errs, validateEnum(name, v)...)
+	}
+	return errs
+}
+
+func searchError(what string, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid %s: %w", what, errors.Join(errs...))
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassSearchBuild(t *testing.T) {
+	from := time.Date(2026, 4, 10, 9, 30, 0, 0, time.FixedZone("CEST", 2*3600))
+
+	params, err := NewClassSearch().
+		Tenants("tenant-1", "tenant-2").
+		From(from).
+		Status(ClassStatusPending).
+		Type(ClassTypeCourse).
+		IncludeSummary().
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	values := params.ToURLValues()
+	if got := values.Get("from_start_date"); got != "2026-04-10T07:30:00" {
+		t.Errorf("Expected from_start_date in UTC, got %q", got)
+	}
+	if got := values.Get("tenant_id"); got != "tenant-1,tenant-2" {
+		t.Errorf("Expected both tenants, got %q", got)
+	}
+	if got := values.Get("status"); got != "PENDING" {
+		t.Errorf("Expected status PENDING, got %q", got)
+	}
+	if got := values.Get("size"); got != "50" {
+		t.Errorf("Expected default size 50, got %q", got)
+	}
+}
+
+func TestClassSearchValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  *ClassSearch
+		wantErr []string
+	}{
+		{
+			name:    "coordinate with tenants",
+			search:  NewClassSearch().Tenants("tenant-1").Near(Coordinate{Lat: 52.5, Lon: 13.4}, 5000),
+			wantErr: []string{"coordinate and tenants can't be combined"},
+		},
+		{
+			name:    "bad coordinate and radius",
+			search:  NewClassSearch().Near(Coordinate{Lat: 95, Lon: 13.4}, 0),
+			wantErr: []string{"latitude 95 is out of range", "radius must be positive"},
+		},
+		{
+			name:    "page size too large",
+			search:  NewClassSearch().PageSize(100),
+			wantErr: []string{"page size 100 exceeds the maximum of 50"},
+		},
+		{
+			name:    "unknown status",
+			search:  NewClassSearch().Status("PENDNG"),
+			wantErr: []string{`status: unknown value "PENDNG"`, "PENDING"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.search.Build()
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got %q", want, err)
+				}
+			}
+		})
+	}
+
+	if _, err := NewClassSearch().Near(Coordinate{Lat: 52.5, Lon: 13.4}, 5000).Build(); err != nil {
+		t.Errorf("Expected a coordinate search to be valid, got %v", err)
+	}
+}
+
+func TestOtherSearchBuilders(t *testing.T) {
+	from := time.Date(2026, 4, 10, 8, 0, 0, 0, time.UTC)
+
+	matches, err := NewMatchSearch().Tenants("tenant-1").Sport(SportPadel).From(from).Build()
+	if err != nil {
+		t.Fatalf("MatchSearch: %v", err)
+	}
+	if matches.FromStartDate != "2026-04-10T08:00:00" || matches.SportID != SportPadel {
+		t.Errorf("Unexpected match params %+v", matches)
+	}
+	if _, err := NewMatchSearch().Page(-1).Build(); err == nil {
+		t.Error("Expected an error for a negative page")
+	}
+
+	lessons, err := NewLessonSearch().Tenant("tenant-1").Status(TournamentStatusRegistrationOpen).Build()
+	if err != nil {
+		t.Fatalf("LessonSearch: %v", err)
+	}
+	if lessons.TenantID != "tenant-1" || len(lessons.Status) != 1 {
+		t.Errorf("Unexpected lesson params %+v", lessons)
+	}
+
+	if _, err := NewTournamentSearch().Visibility("SECRET").Build(); err == nil {
+		t.Error("Expected an error for an unknown visibility")
+	}
+}
+
+func TestAvailabilitySearchBuild(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	params, err := NewAvailabilitySearch("tenant-1").Day(time.Date(2026, 4, 10, 15, 0, 0, 0, berlin), berlin).Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if params.StartMin != "2026-04-09T22:00:00" || params.StartMax != "2026-04-10T21:59:59" {
+		t.Errorf("Expected the Berlin day in UTC, got %s..%s", params.StartMin, params.StartMax)
+	}
+	if params.SportID != SportPadel {
+		t.Errorf("Expected sport to default to PADEL, got %s", params.SportID)
+	}
+
+	start := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		search  *AvailabilitySearch
+		wantErr string
+	}{
+		{"no window", NewAvailabilitySearch("tenant-1"), "window is required"},
+		{"no tenant", NewAvailabilitySearch("").Window(start, start.Add(time.Hour)), "tenant is required"},
+		{"inverted", NewAvailabilitySearch("tenant-1").Window(start, start.Add(-time.Hour)), "is not after start"},
+		{"too long", NewAvailabilitySearch("tenant-1").Window(start, start.Add(26*time.Hour)), "exceeds the maximum of 25h0m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.search.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// A DST day is 25h long and still fits.
+	if _, err := NewAvailabilitySearch("tenant-1").Day(time.Date(2026, 10, 25, 12, 0, 0, 0, berlin), berlin).Build(); err != nil {
+		t.Errorf("Expected the DST fall-back day to be valid, got %v", err)
+	}
+}
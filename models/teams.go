@@ -8,3 +8,21 @@ type Team struct {
 	MaxPlayers int      `json:"max_players"`
 	TeamResult *string  `json:"team_result"`
 }
+
+// FreePositions returns how many more players the team can take.
+func (t Team) FreePositions() int {
+	return freePlaces(t.MaxPlayers, len(t.Players))
+}
+
+// AverageLevel returns the mean level of the team's players, or 0 when the
+// team is empty.
+func (t Team) AverageLevel() float64 {
+	if len(t.Players) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, p := range t.Players {
+		sum += p.LevelValue
+	}
+	return sum / float64(len(t.Players))
+}
@@ -1,12 +1,23 @@
 package models
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
 // TimeFormat is the standard time format used by Playtomic API
 const TimeFormat = "2006-01-02T15:04:05"
 
+// DateFormat is the date-only format used by Playtomic API, e.g. in
+// CourtAvailability.StartDate
+const DateFormat = "2006-01-02"
+
+// TimeOfDayFormat is the time-only format used by Playtomic API, e.g. in
+// Slot.StartTime
+const TimeOfDayFormat = "15:04:05"
+
 // ParseTime parses a time string in Playtomic's format
 func ParseTime(timeStr string) (time.Time, error) {
 	return time.Parse(TimeFormat, timeStr)
@@ -16,3 +27,218 @@ func ParseTime(timeStr string) (time.Time, error) {
 func FormatTime(t time.Time) string {
 	return t.Format(TimeFormat)
 }
+
+// FormatTimeUTC formats t converted to UTC, the form the API sends and
+// expects timestamps in.
+func FormatTimeUTC(t time.Time) string {
+	return FormatTime(t.UTC())
+}
+
+// Time is a Playtomic timestamp. The API sends timestamps in UTC without an
+// offset ("2006-01-02T15:04:05"); Time decodes them as UTC and encodes them
+// back the same way. A null or empty value decodes to the zero Time.
+type Time struct {
+	time.Time
+}
+
+// NewTime returns t as a Time.
+func NewTime(t time.Time) Time {
+	return Time{Time: t.UTC()}
+}
+
+// MustParseTime is like ParseTime but returns a Time and panics if s can't
+// be parsed. It's meant for tests and fixtures with constant inputs.
+func MustParseTime(s string) Time {
+	t, err := ParseTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return Time{Time: t}
+}
+
+// String formats t in Playtomic's format, or returns "" for the zero Time.
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return FormatTimeUTC(t.Time)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides Playtomic's offset-less
+// format it accepts RFC 3339, which a few endpoints use.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteJSON(data)
+	if err != nil || !ok {
+		*t = Time{}
+		return err
+	}
+
+	parsed, err := ParseTime(s)
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("parsing time %q: %w", s, err)
+		}
+	}
+	*t = Time{Time: parsed.UTC()}
+	return nil
+}
+
+// Date is a calendar date as sent by Playtomic ("2006-01-02"). The embedded
+// time.Time is midnight UTC on that date.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the calendar date of t (in t's own location) as a Date.
+func NewDate(t time.Time) Date {
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date string in Playtomic's format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateFormat, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Time: t}, nil
+}
+
+// MustParseDate is like ParseDate but panics if s can't be parsed.
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String formats d in Playtomic's format, or returns "" for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateFormat)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteJSON(data)
+	if err != nil || !ok {
+		*d = Date{}
+		return err
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return fmt.Errorf("parsing date %q: %w", s, err)
+	}
+	*d = parsed
+	return nil
+}
+
+// TimeOfDay is a wall-clock time as sent by Playtomic ("15:04:05"), with no
+// date or zone attached. Slot start times are UTC.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseTimeOfDay parses "HH:MM:SS" or "HH:MM".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse(TimeOfDayFormat, s)
+	if err != nil {
+		t, err = time.Parse("15:04", s)
+		if err != nil {
+			return TimeOfDay{}, err
+		}
+	}
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}, nil
+}
+
+// MustParseTimeOfDay is like ParseTimeOfDay but panics if s can't be parsed.
+func MustParseTimeOfDay(s string) TimeOfDay {
+	t, err := ParseTimeOfDay(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Minutes returns the number of whole minutes since midnight.
+func (t TimeOfDay) Minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// On returns the instant at this time of day on date d, in loc.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour, t.Minute, t.Second, 0, loc)
+}
+
+// String formats t as "HH:MM:SS".
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquoteJSON(data)
+	if err != nil || !ok {
+		*t = TimeOfDay{}
+		return err
+	}
+
+	parsed, err := ParseTimeOfDay(s)
+	if err != nil {
+		return fmt.Errorf("parsing time of day %q: %w", s, err)
+	}
+	*t = parsed
+	return nil
+}
+
+// unquoteJSON decodes a JSON string, reporting ok=false for null or "".
+func unquoteJSON(data []byte) (string, bool, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return "", false, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", false, err
+	}
+	return s, s != "", nil
+}
+
+// Location returns the address's time zone, falling back to UTC when it's
+// missing or unknown to the local tz database.
+func (a Address) Location() *time.Location {
+	if a.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
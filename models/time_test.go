@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -31,4 +32,106 @@ func TestFormatTime(t *testing.T) {
 	if formatted != expected {
 		t.Errorf("Expected %q, got %q", expected, formatted)
 	}
+
+	// FormatTime keeps t's wall clock; FormatTimeUTC converts first.
+	berlin := time.FixedZone("CEST", 2*60*60)
+	local := time.Date(2023, 5, 15, 16, 30, 0, 0, berlin)
+	if got := FormatTime(local); got != "2023-05-15T16:30:00" {
+		t.Errorf("Expected FormatTime to keep the wall clock, got %q", got)
+	}
+	if got := FormatTimeUTC(local); got != expected {
+		t.Errorf("Expected FormatTimeUTC %q, got %q", expected, got)
+	}
+}
+
+func TestTimeJSON(t *testing.T) {
+	var v struct {
+		Start  Time `json:"start"`
+		Closes Time `json:"closes"`
+		Offset Time `json:"offset"`
+	}
+	data := []byte(`{"start":"2026-03-29T00:30:00","closes":null,"offset":"2026-03-29T02:30:00+02:00"}`)
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("Expected successful decoding, got error: %v", err)
+	}
+
+	if !v.Start.Equal(time.Date(2026, 3, 29, 0, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected start decoded as UTC, got %v", v.Start)
+	}
+	if !v.Closes.IsZero() {
+		t.Errorf("Expected null to decode to the zero Time, got %v", v.Start)
+	}
+	if !v.Offset.Equal(v.Start.Time) {
+		t.Errorf("Expected RFC 3339 input to be accepted, got %v", v.Offset)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Expected successful encoding, got error: %v", err)
+	}
+	expected := `{"start":"2026-03-29T00:30:00","closes":null,"offset":"2026-03-29T00:30:00"}`
+	if string(out) != expected {
+		t.Errorf("Expected %s, got %s", expected, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"start":"yesterday"}`), &v); err == nil {
+		t.Errorf("Expected error for invalid time, got no error")
+	}
+}
+
+func TestDateAndTimeOfDayJSON(t *testing.T) {
+	var c CourtAvailability
+	data := []byte(`{"resource_id":"court-1","start_date":"2026-10-25","slots":[{"start_time":"16:30:00","duration":90,"price":"36 EUR"}]}`)
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("Expected successful decoding, got error: %v", err)
+	}
+
+	if c.StartDate.String() != "2026-10-25" {
+		t.Errorf("Expected date 2026-10-25, got %s", c.StartDate)
+	}
+	slot := c.Slots[0]
+	if slot.StartTime != (TimeOfDay{Hour: 16, Minute: 30}) {
+		t.Errorf("Expected 16:30:00, got %s", slot.StartTime)
+	}
+	if slot.StartTime.Minutes() != 990 {
+		t.Errorf("Expected 990 minutes, got %d", slot.StartTime.Minutes())
+	}
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Expected successful encoding, got error: %v", err)
+	}
+	if string(out) != string(data) {
+		t.Errorf("Expected round trip to %s, got %s", data, out)
+	}
+}
+
+func TestLocalAccessors(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tz database unavailable: %v", err)
+	}
+
+	class := Class{
+		StartDate: MustParseTime("2026-07-01T16:00:00"),
+		Tenant:    Tenant{Address: Address{Timezone: "Europe/Berlin"}},
+	}
+	if got := class.Start().Format("15:04 MST"); got != "18:00 CEST" {
+		t.Errorf("Expected class to start at 18:00 CEST, got %s", got)
+	}
+	if class.Start().Location().String() != berlin.String() {
+		t.Errorf("Expected tenant location, got %s", class.Start().Location())
+	}
+
+	// Unknown or missing time zones fall back to UTC.
+	class.Tenant.Address.Timezone = "UTC+1"
+	if class.Start().Location() != time.UTC {
+		t.Errorf("Expected UTC fallback, got %s", class.Start().Location())
+	}
+
+	court := CourtAvailability{StartDate: MustParseDate("2026-01-15")}
+	start := court.SlotStart(Slot{StartTime: MustParseTimeOfDay("17:00:00")}, berlin)
+	if got := start.Format("2006-01-02 15:04 MST"); got != "2026-01-15 18:00 CET" {
+		t.Errorf("Expected slot to start at 18:00 CET, got %s", got)
+	}
 }
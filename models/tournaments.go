@@ -3,15 +3,30 @@ package models
 import (
 	"net/url"
 	"strings"
+	"time"
 )
 
 type Tournament struct {
 	TournamentID    string           `json:"tournament_id"`
 	Name            string           `json:"name"`
-	Visibility      string           `json:"visibility"`
+	Visibility      Visibility       `json:"visibility"`
 	AvailablePlaces int              `json:"available_places"`
-	Status          string           `json:"status"`
+	Status          TournamentStatus `json:"status"`
 	Teams           []TournamentTeam `json:"teams"`
+	StartDate       Time             `json:"start_date"`
+	EndDate         Time             `json:"end_date"`
+	Price           Money            `json:"price"`
+	Tenant          LessonTenant     `json:"tenant"` // Same shape as a lesson's tenant
+}
+
+// Start returns the tournament start time in the tenant's time zone.
+func (t Tournament) Start() time.Time {
+	return t.StartDate.In(t.Tenant.TenantAddress.Location())
+}
+
+// End returns the tournament end time in the tenant's time zone.
+func (t Tournament) End() time.Time {
+	return t.EndDate.In(t.Tenant.TenantAddress.Location())
 }
 
 type TournamentTeam struct {
@@ -26,10 +41,10 @@ type TournamentPlayer struct {
 
 type SearchTournamentsParams struct {
 	AvailablePlaces    bool
-	RegistrationStatus string
-	Status             string
+	RegistrationStatus RegistrationStatus
+	Status             []TournamentStatus
 	TenantID           string
-	Visibility         string
+	Visibility         Visibility
 }
 
 func (p *SearchTournamentsParams) ToURLValues() url.Values {
@@ -39,16 +54,16 @@ func (p *SearchTournamentsParams) ToURLValues() url.Values {
 		values.Set("available_places", "true")
 	}
 
-	if rs := strings.TrimSpace(p.RegistrationStatus); rs != "" {
+	if rs := strings.TrimSpace(string(p.RegistrationStatus)); rs != "" {
 		values.Set("registration_status", rs)
 	}
-	if s := strings.TrimSpace(p.Status); s != "" {
+	if s := joinEnums(p.Status); s != "" {
 		values.Set("status", s)
 	}
 	if t := strings.TrimSpace(p.TenantID); t != "" {
 		values.Set("tenant_id", t)
 	}
-	if v := strings.TrimSpace(p.Visibility); v != "" {
+	if v := strings.TrimSpace(string(p.Visibility)); v != "" {
 		values.Set("visibility", v)
 	}
 